@@ -0,0 +1,322 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/credhub-cli/config"
+	"code.cloudfoundry.org/credhub-cli/credhub"
+	"code.cloudfoundry.org/credhub-cli/errors"
+	"github.com/jessevdk/go-flags"
+)
+
+// agentURL is the target of clients that talk to the agent. The host is
+// ignored, since connections are made to the agent socket.
+const agentURL = "http://credhub-agent"
+
+type AgentCommand struct {
+	Socket string        `long:"socket" env:"CREDHUB_AGENT_SOCK" description:"Path of the Unix socket to listen on (Default: ~/.credhub/agent.sock)"`
+	TTL    time.Duration `long:"ttl" default:"5m" description:"Time for which responses are served from the cache"`
+	ClientCommand
+}
+
+type agentCacheEntry struct {
+	name    string
+	status  int
+	body    []byte
+	expires time.Time
+}
+
+// agentHandler serves requests from the CLI with the agent's authenticated
+// client, caching successful get and find responses for the TTL. Requests
+// that change a credential are passed through and flush the cached responses
+// they could make stale. Other changes, e.g. bulk regenerate or certificate
+// updates, flush the whole cache.
+type agentHandler struct {
+	client *credhub.CredHub
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]agentCacheEntry
+}
+
+func (c *AgentCommand) Execute([]string) error {
+	if c.TTL < 0 {
+		return errors.NewInvalidAgentTTLError()
+	}
+
+	socket := c.Socket
+	if socket == "" {
+		socket = filepath.Join(config.ConfigDir(), "agent.sock")
+	}
+
+	listener, err := listenOnAgentSocket(socket)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(socket)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		listener.Close()
+	}()
+
+	handler := &agentHandler{client: c.client, ttl: c.TTL, cache: map[string]agentCacheEntry{}}
+
+	fmt.Printf("Serving credentials on %s. Set CREDHUB_AGENT_SOCK=%s to use the agent.\n", socket, socket)
+
+	err = http.Serve(listener, handler)
+	if err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
+		return err
+	}
+
+	return nil
+}
+
+// listenOnAgentSocket listens on a socket that only the current user can
+// connect to. A socket left behind by an agent that is no longer running is replaced.
+func listenOnAgentSocket(socket string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(socket), 0700); err != nil {
+		return nil, err
+	}
+
+	if conn, err := net.Dial("unix", socket); err == nil {
+		conn.Close()
+		return nil, errors.NewAgentAlreadyRunningError(socket)
+	}
+	os.Remove(socket)
+
+	listener, err := listenPrivateUnix(socket)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(socket, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+func (h *agentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "GET" && agentServesPath(r.URL.Path):
+		h.serveRead(w, r)
+	case (r.Method == "PUT" || r.Method == "POST" || r.Method == "DELETE") && r.URL.Path == "/api/v1/data":
+		h.serveWrite(w, r)
+	case r.Method == "GET" || (r.Method == "POST" && r.URL.Path == "/api/v1/interpolate"):
+		h.servePassthrough(w, r, false)
+	default:
+		h.servePassthrough(w, r, true)
+	}
+}
+
+func (h *agentHandler) serveRead(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Path + "?" + r.URL.Query().Encode()
+
+	entry, ok := h.cached(key)
+	if !ok {
+		var err error
+		entry, err = h.forward(r.Method, r.URL.Path, r.URL.Query(), nil)
+		if err != nil {
+			writeAgentError(w, err)
+			return
+		}
+
+		if entry.status == http.StatusOK {
+			if name := r.URL.Query().Get("name"); name != "" {
+				entry.name = agentCacheName(name)
+			}
+			entry.expires = time.Now().Add(h.ttl)
+			h.store(key, entry)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(entry.status)
+	w.Write(entry.body)
+}
+
+func (h *agentHandler) serveWrite(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+
+	var requestBody interface{}
+	if r.Method != "DELETE" {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var credential struct {
+			Name string `json:"name"`
+		}
+		json.Unmarshal(body, &credential)
+		name = credential.Name
+		requestBody = json.RawMessage(body)
+	}
+
+	entry, err := h.forward(r.Method, r.URL.Path, r.URL.Query(), requestBody)
+	h.flush(agentCacheName(name))
+	if err != nil {
+		writeAgentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(entry.status)
+	w.Write(entry.body)
+}
+
+// servePassthrough forwards the request without caching the response. When the
+// request can change credentials, the whole cache is flushed.
+func (h *agentHandler) servePassthrough(w http.ResponseWriter, r *http.Request, flush bool) {
+	var requestBody interface{}
+	if r.Body != nil {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if len(body) > 0 {
+			requestBody = json.RawMessage(body)
+		}
+	}
+
+	entry, err := h.forward(r.Method, r.URL.Path, r.URL.Query(), requestBody)
+	if flush {
+		h.flushAll()
+	}
+	if err != nil {
+		writeAgentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(entry.status)
+	w.Write(entry.body)
+}
+
+// forward sends the request to the CredHub server with the agent's client
+func (h *agentHandler) forward(method, path string, query url.Values, body interface{}) (agentCacheEntry, error) {
+	resp, err := h.client.Request(method, path, query, body, false)
+	if err != nil {
+		return agentCacheEntry{}, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return agentCacheEntry{}, err
+	}
+
+	return agentCacheEntry{status: resp.StatusCode, body: responseBody}, nil
+}
+
+func writeAgentError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadGateway)
+	body, _ := json.Marshal(map[string]string{"error": err.Error()})
+	w.Write(body)
+}
+
+func (h *agentHandler) cached(key string) (agentCacheEntry, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entry, ok := h.cache[key]
+	if ok && time.Now().After(entry.expires) {
+		delete(h.cache, key)
+		return agentCacheEntry{}, false
+	}
+	return entry, ok
+}
+
+func (h *agentHandler) store(key string, entry agentCacheEntry) {
+	if h.ttl == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for k, e := range h.cache {
+		if time.Now().After(e.expires) {
+			delete(h.cache, k)
+		}
+	}
+	h.cache[key] = entry
+}
+
+// flush removes the cached responses for the named credential, along with the
+// find and get-by-id responses, which could also include it
+func (h *agentHandler) flush(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for k, e := range h.cache {
+		if e.name == name || (e.name == "" && strings.HasPrefix(k, "/api/v1/data")) {
+			delete(h.cache, k)
+		}
+	}
+}
+
+// flushAll removes all cached responses
+func (h *agentHandler) flushAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.cache = map[string]agentCacheEntry{}
+}
+
+// agentCacheName returns the name the server stores the credential under,
+// which always starts with a slash
+func agentCacheName(name string) string {
+	if strings.HasPrefix(name, "/") {
+		return name
+	}
+	return "/" + name
+}
+
+func agentServesPath(path string) bool {
+	return path == "/info" || path == "/version" || path == "/api/v1/data" || strings.HasPrefix(path, "/api/v1/data/")
+}
+
+// ServedByAgent returns whether the command can be served by a running
+// credential agent instead of the CredHub server. Commands that change a
+// credential go through the agent too, so that it does not serve stale values.
+func ServedByAgent(command flags.Commander) bool {
+	switch command.(type) {
+	case *GetCommand, *FindCommand, *ExportCommand, *InterpolateCommand,
+		*SetCommand, *GenerateCommand, *RegenerateCommand, *BulkRegenerateCommand, *DeleteCommand,
+		*EditCommand, *ImportCommand, *RollbackCommand, *RotateCommand:
+		return true
+	}
+	return false
+}
+
+// NewAgentClient returns a client that sends requests to the credential
+// agent listening on the given socket
+func NewAgentClient(socket string) (*credhub.CredHub, error) {
+	return credhub.New(agentURL, credhub.HTTPClient(&http.Client{
+		Transport: &http.Transport{
+			Dial: func(network, address string) (net.Conn, error) {
+				return net.Dial("unix", socket)
+			},
+		},
+	}))
+}
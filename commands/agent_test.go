@@ -0,0 +1,197 @@
+package commands_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Agent", func() {
+	var (
+		socketDir string
+		socket    string
+	)
+
+	BeforeEach(func() {
+		login()
+
+		var err error
+		socketDir, err = ioutil.TempDir("", "credhub-agent")
+		Expect(err).NotTo(HaveOccurred())
+		socket = filepath.Join(socketDir, "agent.sock")
+	})
+
+	AfterEach(func() {
+		KillAndWait()
+		os.RemoveAll(socketDir)
+	})
+
+	ItRequiresAuthentication("agent", "--socket", "/tmp/credhub-agent-test.sock")
+	ItRequiresAnAPIToBeSet("agent", "--socket", "/tmp/credhub-agent-test.sock")
+
+	Describe("Help", func() {
+		ItBehavesLikeHelp("agent", "agent", func(session *Session) {
+			Expect(session.Err).To(Say("agent"))
+			Expect(session.Err).To(Say("socket"))
+		})
+	})
+
+	Context("when the agent is running", func() {
+		var agent *Session
+
+		BeforeEach(func() {
+			agent = startCommand("agent", "--socket", socket)
+			Eventually(agent.Out).Should(Say("Serving credentials on %s", regexp.QuoteMeta(socket)))
+		})
+
+		It("only allows the current user to connect", func() {
+			info, err := os.Stat(socket)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		})
+
+		It("serves get requests from its cache", func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				CombineHandlers(
+					VerifyRequest("GET", "/api/v1/data", "current=true&name=my-password"),
+					RespondWith(http.StatusOK, fmt.Sprintf(STRING_CREDENTIAL_ARRAY_RESPONSE_JSON, "password", "my-password", "potatoes")),
+				),
+			)
+
+			env := []string{"CREDHUB_AGENT_SOCK=" + socket}
+			for i := 0; i < 2; i++ {
+				session := runCommandWithEnv(env, "get", "-n", "my-password")
+
+				Eventually(session).Should(Exit(0))
+				Expect(session.Out).To(Say("name: my-password"))
+				Expect(session.Out).To(Say("value: potatoes"))
+			}
+
+			dataRequests := 0
+			for _, request := range server.ReceivedRequests() {
+				if request.URL.Path == "/api/v1/data" {
+					dataRequests++
+				}
+			}
+			Expect(dataRequests).To(Equal(1))
+		})
+
+		It("flushes the cached value when the credential is changed through the agent", func() {
+			value := "potatoes"
+			server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, STRING_CREDENTIAL_ARRAY_RESPONSE_JSON, "password", "/my-password", value)
+			})
+			server.RouteToHandler("PUT", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+				value = "tomatoes"
+				fmt.Fprintf(w, SET_STRING_CREDENTIAL_RESPONSE_JSON, "password", "/my-password")
+			})
+
+			env := []string{"CREDHUB_AGENT_SOCK=" + socket}
+			session := runCommandWithEnv(env, "get", "-n", "my-password")
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("value: potatoes"))
+
+			session = runCommandWithEnv(env, "set", "-n", "my-password", "-t", "password", "-w", "tomatoes")
+			Eventually(session).Should(Exit(0))
+
+			session = runCommandWithEnv(env, "get", "-n", "my-password")
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("value: tomatoes"))
+
+			putRequests := 0
+			for _, request := range server.ReceivedRequests() {
+				if request.Method == "PUT" && request.URL.Path == "/api/v1/data" {
+					putRequests++
+				}
+			}
+			Expect(putRequests).To(Equal(1))
+		})
+
+		It("flushes the cache when certificates are regenerated in bulk through the agent", func() {
+			value := "potatoes"
+			server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, STRING_CREDENTIAL_ARRAY_RESPONSE_JSON, "password", "/my-password", value)
+			})
+			server.RouteToHandler("POST", "/api/v1/bulk-regenerate", func(w http.ResponseWriter, r *http.Request) {
+				value = "tomatoes"
+				w.Write([]byte(`{"regenerated_credentials":["/my-password"]}`))
+			})
+
+			env := []string{"CREDHUB_AGENT_SOCK=" + socket}
+			session := runCommandWithEnv(env, "get", "-n", "my-password")
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("value: potatoes"))
+
+			session = runCommandWithEnv(env, "bulk-regenerate", "--signed-by", "example-ca")
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("- /my-password"))
+
+			session = runCommandWithEnv(env, "get", "-n", "my-password")
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("value: tomatoes"))
+		})
+
+		It("serves interpolated credentials from its cache", func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				RespondWith(http.StatusOK, fmt.Sprintf(STRING_CREDENTIAL_ARRAY_RESPONSE_JSON, "password", "/my-password", "potatoes")),
+			)
+
+			templateFile, err := ioutil.TempFile("", "credhub-agent-interpolate")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.Remove(templateFile.Name())
+			templateFile.WriteString("password: ((/my-password))\n")
+			templateFile.Close()
+
+			env := []string{"CREDHUB_AGENT_SOCK=" + socket}
+			for i := 0; i < 2; i++ {
+				session := runCommandWithEnv(env, "interpolate", "-f", templateFile.Name())
+
+				Eventually(session).Should(Exit(0))
+				Expect(session.Out).To(Say("password: potatoes"))
+			}
+
+			dataRequests := 0
+			for _, request := range server.ReceivedRequests() {
+				if request.URL.Path == "/api/v1/data" {
+					dataRequests++
+				}
+			}
+			Expect(dataRequests).To(Equal(1))
+		})
+
+		It("passes errors from the server through", func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				RespondWith(http.StatusNotFound, `{"error":"The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`),
+			)
+
+			session := runCommandWithEnv([]string{"CREDHUB_AGENT_SOCK=" + socket}, "get", "-n", "my-password")
+
+			Eventually(session).Should(Exit(2))
+			Expect(session.Err).To(Say("The request could not be completed because the credential does not exist or you do not have sufficient authorization."))
+		})
+
+		It("refuses to start a second agent on the same socket", func() {
+			session := runCommand("agent", "--socket", socket)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(`A credential agent is already listening on '%s'\.`, regexp.QuoteMeta(socket)))
+		})
+
+		It("removes the socket when it is stopped", func() {
+			agent.Interrupt()
+
+			Eventually(agent).Should(Exit(0))
+			_, err := os.Stat(socket)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,17 @@
+// +build !windows
+
+package commands
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenPrivateUnix creates the socket with permissions for the current user
+// only, so that other users cannot connect before its mode is set
+func listenPrivateUnix(socket string) (net.Listener, error) {
+	oldMask := syscall.Umask(0077)
+	defer syscall.Umask(oldMask)
+
+	return net.Listen("unix", socket)
+}
@@ -0,0 +1,9 @@
+// +build windows
+
+package commands
+
+import "net"
+
+func listenPrivateUnix(socket string) (net.Listener, error) {
+	return net.Listen("unix", socket)
+}
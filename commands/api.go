@@ -2,8 +2,9 @@ package commands
 
 import (
 	"fmt"
-
+	"net"
 	"net/url"
+	"strings"
 
 	"code.cloudfoundry.org/credhub-cli/config"
 	"code.cloudfoundry.org/credhub-cli/credhub"
@@ -21,6 +22,8 @@ type ApiCommand struct {
 	ServerFlagUrl     string            `short:"s" long:"server" description:"URI of API server to target" env:"CREDHUB_SERVER"`
 	CaCerts           []string          `long:"ca-cert" description:"Trusted CA for API and UAA TLS connections. Multiple flags may be provided." env:"CREDHUB_CA_CERT"`
 	SkipTlsValidation bool              `long:"skip-tls-validation" description:"Skip certificate validation of the API endpoint. Not recommended!"`
+	AuthType          string            `long:"auth-type" description:"Type of the auth server of the API, 'uaa' or 'oidc' for any OpenID Connect provider (Default: uaa)" env:"CREDHUB_AUTH_TYPE"`
+	Proxy             string            `long:"proxy" description:"Proxy for API and UAA connections, e.g. 'socks5://localhost:1080' or 'ssh+socks5://jumpbox@jumpbox.example.com:22?private-key=/path/to/key&known-hosts=/path/to/known_hosts'. Overrides CREDHUB_PROXY and BOSH_ALL_PROXY for this target."`
 	ConfigCommand
 }
 
@@ -49,14 +52,31 @@ func (c *ApiCommand) Execute([]string) error {
 	}
 	newConfig.CaCerts = caCerts
 	newConfig.InsecureSkipVerify = c.SkipTlsValidation
+	newConfig.Proxy = c.Proxy
+
+	switch authType := strings.ToLower(c.AuthType); authType {
+	case "", config.AuthTypeUaa:
+	case config.AuthTypeOidc:
+		newConfig.AuthType = authType
+	default:
+		return errors.NewInvalidAuthTypeError(c.AuthType)
+	}
+
+	proxyURL := newConfig.Proxy
+	if proxyURL == "" {
+		proxyURL = credhub.ProxyFromEnvironment()
+	}
+	if _, err := credhub.ProxyDialFunc(proxyURL, (&net.Dialer{}).Dial, nil); err != nil {
+		return err
+	}
 
-	credhubInfo, err := GetApiInfo(newConfig.ApiURL, newConfig.CaCerts, newConfig.InsecureSkipVerify)
+	credhubInfo, err := GetApiInfo(newConfig)
 	if err != nil {
 		return errors.NewNetworkError(err)
 	}
 	newConfig.AuthURL = credhubInfo.AuthServer.URL
 
-	if newConfig.AuthURL != c.config.AuthURL {
+	if newConfig.AuthURL != c.config.AuthURL || newConfig.AuthType != c.config.AuthType {
 		RevokeTokenIfNecessary(c.config)
 		MarkTokensAsRevokedInConfig(&c.config)
 	}
@@ -76,8 +96,8 @@ func (c *ApiCommand) Execute([]string) error {
 	return config.WriteConfig(newConfig)
 }
 
-func GetApiInfo(serverUrl string, caCerts []string, skipTlsValidation bool) (*server.Info, error) {
-	credhubClient, err := credhub.New(serverUrl, credhub.CaCerts(caCerts...), credhub.SkipTLSValidation(skipTlsValidation))
+func GetApiInfo(cfg config.Config) (*server.Info, error) {
+	credhubClient, err := credhub.New(cfg.ApiURL, credhub.CaCerts(cfg.CaCerts...), credhub.SkipTLSValidation(cfg.InsecureSkipVerify), credhub.Proxy(cfg.Proxy))
 	if err != nil {
 		return nil, err
 	}
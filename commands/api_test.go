@@ -113,7 +113,7 @@ var _ = Describe("API", func() {
 
 			session := runCommand("api", apiServer.URL())
 
-			Eventually(session).Should(Exit(1))
+			Eventually(session).Should(Exit(7))
 			newCfg := config.ReadConfig()
 			Expect(newCfg.AccessToken).To(Equal("fake_token"))
 			Expect(newCfg.RefreshToken).To(Equal("fake_refresh"))
@@ -305,7 +305,7 @@ var _ = Describe("API", func() {
 						theServerUrl = setUpServer(theServer)
 						session := runCommand("api", "-s", theServerUrl)
 
-						Eventually(session).Should(Exit(1))
+						Eventually(session).Should(Exit(7))
 						Eventually(session.Err).Should(Say("Error connecting to the targeted API"))
 					})
 
@@ -382,7 +382,7 @@ var _ = Describe("API", func() {
 					previousCfg := config.ReadConfig()
 					session := runCommand("api", "-s", server.URL(), "--ca-cert", "../test/auth-tls-ca.pem")
 
-					Eventually(session).Should(Exit(1))
+					Eventually(session).Should(Exit(7))
 					Eventually(session.Err).Should(Say("certificate signed by unknown authority"))
 
 					cfg := config.ReadConfig()
@@ -393,7 +393,7 @@ var _ = Describe("API", func() {
 					previousCfg := config.ReadConfig()
 					session := runCommand("api", "-s", server.URL(), "--ca-cert", "../test/server-tls-ca.pem")
 
-					Eventually(session).Should(Exit(1))
+					Eventually(session).Should(Exit(7))
 					Eventually(session.Err).Should(Say("certificate signed by unknown authority"))
 
 					cfg := config.ReadConfig()
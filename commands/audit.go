@@ -0,0 +1,400 @@
+package commands
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
+	"code.cloudfoundry.org/credhub-cli/errors"
+)
+
+type AuditCommand struct {
+	Passwords AuditPasswordsCommand `command:"passwords" description:"Report passwords that are reused or do not meet a policy" long-description:"Get the current version of every password, user and value credential under the path. Credentials that share the same password are grouped by a keyed hash of the password, which is only valid for this report. The strength of each password is estimated from its length, character classes and entropy, and reported if it does not meet the policy. Passwords are never printed. Exits with a non-zero status if reused or weak passwords are found."`
+	Age       AuditAgeCommand       `command:"age" description:"Report credentials that were not updated within a maximum age" long-description:"Report credentials under the path whose current version is older than the maximum age, e.g. to show that credentials are rotated regularly. Credentials are grouped by type and by the path they are directly under, with the oldest first. The report is printed as a table, or in the format given with --output, which also accepts 'table' and 'csv'."`
+}
+
+type AuditPasswordsCommand struct {
+	Path                string  `short:"p" long:"path" required:"yes" description:"Path of credentials to audit"`
+	MinLength           int     `long:"min-length" default:"20" description:"Minimum number of characters of a password"`
+	MinCharacterClasses int     `long:"min-character-classes" default:"3" description:"Minimum number of character classes (lowercase, uppercase, digits and symbols) in a password"`
+	MinEntropy          float64 `long:"min-entropy" default:"100" description:"Minimum estimated entropy of a password in bits"`
+	OutputCommand
+	ClientCommand
+}
+
+type passwordPolicy struct {
+	MinLength           int     `json:"min_length" yaml:"min_length"`
+	MinCharacterClasses int     `json:"min_character_classes" yaml:"min_character_classes"`
+	MinEntropy          float64 `json:"min_entropy" yaml:"min_entropy"`
+}
+
+type passwordStrength struct {
+	Length           int     `json:"length" yaml:"length"`
+	CharacterClasses int     `json:"character_classes" yaml:"character_classes"`
+	Entropy          float64 `json:"entropy" yaml:"entropy"`
+}
+
+type passwordAuditReport struct {
+	Path       string                 `json:"path" yaml:"path"`
+	Policy     passwordPolicy         `json:"policy" yaml:"policy"`
+	Audited    int                    `json:"audited" yaml:"audited"`
+	Reused     []reusedPasswordGroup  `json:"reused" yaml:"reused"`
+	Violations []passwordPolicyReport `json:"violations" yaml:"violations"`
+}
+
+type reusedPasswordGroup struct {
+	Hash  string   `json:"hash" yaml:"hash"`
+	Names []string `json:"names" yaml:"names"`
+}
+
+type passwordPolicyReport struct {
+	Name             string `json:"name" yaml:"name"`
+	Type             string `json:"type" yaml:"type"`
+	passwordStrength `yaml:",inline"`
+	Violations       []string `json:"violations" yaml:"violations"`
+}
+
+func (c *AuditPasswordsCommand) Execute([]string) error {
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
+	results, err := c.client.FindByPath(c.Path)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(results.Credentials))
+	for i, cred := range results.Credentials {
+		names[i] = cred.Name
+	}
+
+	creds, err := getLatestVersions(c.client, names)
+	if err != nil {
+		return err
+	}
+
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+
+	policy := passwordPolicy{
+		MinLength:           c.MinLength,
+		MinCharacterClasses: c.MinCharacterClasses,
+		MinEntropy:          c.MinEntropy,
+	}
+	report := auditPasswords(creds, policy, key)
+	report.Path = c.Path
+
+	if err := c.printOutput(report); err != nil {
+		return err
+	}
+
+	if len(report.Reused) != 0 || len(report.Violations) != 0 {
+		return errors.NewPasswordAuditFindingsError(len(report.Reused), len(report.Violations))
+	}
+
+	return nil
+}
+
+// auditPasswords groups the credentials that share a password by its HMAC
+// with key and checks the strength of each password against the policy.
+// Credentials without a password are skipped.
+func auditPasswords(creds []credentials.Credential, policy passwordPolicy, key []byte) passwordAuditReport {
+	report := passwordAuditReport{
+		Policy:     policy,
+		Reused:     []reusedPasswordGroup{},
+		Violations: []passwordPolicyReport{},
+	}
+	groups := map[string][]string{}
+
+	for _, cred := range creds {
+		password, ok := auditedPassword(cred)
+		if !ok {
+			continue
+		}
+		report.Audited++
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(password))
+		hash := hex.EncodeToString(mac.Sum(nil))[:16]
+		groups[hash] = append(groups[hash], cred.Name)
+
+		strength := estimatePasswordStrength(password)
+		if violations := policy.violations(strength); len(violations) != 0 {
+			report.Violations = append(report.Violations, passwordPolicyReport{
+				Name:             cred.Name,
+				Type:             cred.Type,
+				passwordStrength: strength,
+				Violations:       violations,
+			})
+		}
+	}
+
+	for hash, names := range groups {
+		if len(names) > 1 {
+			sort.Strings(names)
+			report.Reused = append(report.Reused, reusedPasswordGroup{Hash: hash, Names: names})
+		}
+	}
+	sort.Slice(report.Reused, func(i, j int) bool {
+		return report.Reused[i].Names[0] < report.Reused[j].Names[0]
+	})
+	sort.Slice(report.Violations, func(i, j int) bool {
+		return report.Violations[i].Name < report.Violations[j].Name
+	})
+
+	return report
+}
+
+func auditedPassword(cred credentials.Credential) (string, bool) {
+	switch cred.Type {
+	case "password", "value":
+		password, ok := cred.Value.(string)
+		return password, ok
+	case "user":
+		value, ok := cred.Value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		password, ok := value["password"].(string)
+		return password, ok
+	default:
+		return "", false
+	}
+}
+
+// estimatePasswordStrength estimates the entropy of a password as if each
+// character was chosen at random from all characters of the classes it uses
+func estimatePasswordStrength(password string) passwordStrength {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes, poolSize := 0, 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}} {
+		if class.used {
+			classes++
+			poolSize += class.size
+		}
+	}
+
+	length := utf8.RuneCountInString(password)
+	entropy := 0.0
+	if poolSize > 0 {
+		entropy = math.Floor(float64(length)*math.Log2(float64(poolSize))*10) / 10
+	}
+
+	return passwordStrength{Length: length, CharacterClasses: classes, Entropy: entropy}
+}
+
+func (p passwordPolicy) violations(s passwordStrength) []string {
+	var violations []string
+	if s.Length < p.MinLength {
+		violations = append(violations, "length")
+	}
+	if s.CharacterClasses < p.MinCharacterClasses {
+		violations = append(violations, "character_classes")
+	}
+	if s.Entropy < p.MinEntropy {
+		violations = append(violations, "entropy")
+	}
+	return violations
+}
+
+type AuditAgeCommand struct {
+	Path   string `short:"p" long:"path" required:"yes" description:"Path of credentials to audit"`
+	MaxAge string `long:"max-age" required:"yes" description:"Report credentials whose current version is older than the provided age, e.g. '90d' or '12h'"`
+	Type   string `short:"t" long:"type" description:"Only include credentials of the provided type, e.g. 'password'"`
+	OutputCommand
+	ClientCommand
+}
+
+type credentialAgeReport struct {
+	Path   string                 `json:"path" yaml:"path"`
+	MaxAge string                 `json:"max_age" yaml:"max_age"`
+	Groups []staleCredentialGroup `json:"groups" yaml:"groups"`
+}
+
+// staleCredentialGroup is the stale credentials of one type directly under
+// one path
+type staleCredentialGroup struct {
+	Type        string            `json:"type" yaml:"type"`
+	Path        string            `json:"path" yaml:"path"`
+	Credentials []staleCredential `json:"credentials" yaml:"credentials"`
+}
+
+type staleCredential struct {
+	Name             string `json:"name" yaml:"name"`
+	VersionCreatedAt string `json:"version_created_at" yaml:"version_created_at"`
+	AgeDays          int    `json:"age_days" yaml:"age_days"`
+}
+
+func (c *AuditAgeCommand) Execute([]string) error {
+	if err := c.validateOutputWith("table", "csv"); err != nil {
+		return err
+	}
+
+	maxAge, err := parseAge(c.MaxAge)
+	if err != nil {
+		return err
+	}
+
+	results, err := c.client.FindByPath(c.Path)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var stale []credentials.Base
+	for _, cred := range results.Credentials {
+		if isOlderThan(cred.VersionCreatedAt, maxAge, now) {
+			stale = append(stale, cred)
+		}
+	}
+
+	names := make([]string, len(stale))
+	for i, cred := range stale {
+		names[i] = cred.Name
+	}
+	metadata, err := getLatestMetadata(c.client, names)
+	if err != nil {
+		return err
+	}
+
+	report := credentialAgeReport{
+		Path:   c.Path,
+		MaxAge: c.MaxAge,
+		Groups: groupStaleCredentials(stale, metadata, c.Type, now),
+	}
+
+	switch {
+	case c.isDefaultOutput(), c.outputFormat() == "table":
+		return printCredentialAgeTable(report)
+	case c.outputFormat() == "csv":
+		return printCredentialAgeCSV(report)
+	default:
+		return c.printOutput(report)
+	}
+}
+
+// parseAge parses a duration as accepted by time.ParseDuration, or a number
+// of days, e.g. '90d'
+func parseAge(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err == nil && days > 0 {
+			return time.Duration(days) * 24 * time.Hour, nil
+		}
+	} else if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d, nil
+	}
+
+	return 0, errors.NewInvalidAgeError(value)
+}
+
+// isOlderThan is true when versionCreatedAt is more than age before now.
+// Credentials without a valid creation time are never old.
+func isOlderThan(versionCreatedAt string, age time.Duration, now time.Time) bool {
+	created, err := time.Parse(time.RFC3339, versionCreatedAt)
+	return err == nil && now.Sub(created) > age
+}
+
+// groupStaleCredentials groups the stale credentials by type and by the path
+// they are directly under, sorted by type, path and then with the oldest first.
+// Credentials without metadata are left out.
+func groupStaleCredentials(stale []credentials.Base, metadata map[string]credentials.Metadata, credType string, now time.Time) []staleCredentialGroup {
+	groups := []staleCredentialGroup{}
+	indexes := map[[2]string]int{}
+
+	for _, cred := range stale {
+		m, ok := metadata[cred.Name]
+		if !ok || credType != "" && !strings.EqualFold(m.Type, credType) {
+			continue
+		}
+
+		key := [2]string{m.Type, path.Dir(cred.Name)}
+		g, ok := indexes[key]
+		if !ok {
+			g = len(groups)
+			indexes[key] = g
+			groups = append(groups, staleCredentialGroup{Type: key[0], Path: key[1]})
+		}
+
+		created, _ := time.Parse(time.RFC3339, cred.VersionCreatedAt)
+		groups[g].Credentials = append(groups[g].Credentials, staleCredential{
+			Name:             cred.Name,
+			VersionCreatedAt: cred.VersionCreatedAt,
+			AgeDays:          int(now.Sub(created).Hours() / 24),
+		})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Type != groups[j].Type {
+			return groups[i].Type < groups[j].Type
+		}
+		return groups[i].Path < groups[j].Path
+	})
+	for _, group := range groups {
+		stale := group.Credentials
+		sort.Slice(stale, func(i, j int) bool {
+			if stale[i].AgeDays != stale[j].AgeDays {
+				return stale[i].AgeDays > stale[j].AgeDays
+			}
+			return stale[i].Name < stale[j].Name
+		})
+	}
+
+	return groups
+}
+
+func printCredentialAgeTable(report credentialAgeReport) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tPATH\tNAME\tUPDATED\tAGE (DAYS)")
+	for _, group := range report.Groups {
+		for _, cred := range group.Credentials {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", group.Type, group.Path, cred.Name, cred.VersionCreatedAt, cred.AgeDays)
+		}
+	}
+	return w.Flush()
+}
+
+func printCredentialAgeCSV(report credentialAgeReport) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"type", "path", "name", "version_created_at", "age_days"})
+	for _, group := range report.Groups {
+		for _, cred := range group.Credentials {
+			w.Write([]string{group.Type, group.Path, cred.Name, cred.VersionCreatedAt, strconv.Itoa(cred.AgeDays)})
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
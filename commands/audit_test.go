@@ -0,0 +1,205 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Audit", func() {
+	var currentValues map[string]string
+
+	BeforeEach(func() {
+		login()
+
+		currentValues = map[string]string{}
+
+		server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("path") == "/cf" {
+				fmt.Fprint(w, `{"credentials":[`)
+				first := true
+				for name := range currentValues {
+					if !first {
+						fmt.Fprint(w, ",")
+					}
+					first = false
+					fmt.Fprintf(w, `{"name":"%s","version_created_at":"2017-01-01T00:00:00Z"}`, name)
+				}
+				fmt.Fprint(w, `]}`)
+				return
+			}
+
+			name := r.URL.Query().Get("name")
+			if currentValues[name] == "" {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":"The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`)
+				return
+			}
+			fmt.Fprintf(w, `{"data":[{"id":"some-id","name":"%s",%s,"version_created_at":"2017-01-01T00:00:00Z"}]}`, name, currentValues[name])
+		})
+	})
+
+	ItRequiresAuthentication("audit", "passwords", "-p", "/cf")
+	ItRequiresAnAPIToBeSet("audit", "passwords", "-p", "/cf")
+
+	Describe("Help", func() {
+		ItBehavesLikeHelp("audit", "audit", func(session *Session) {
+			Expect(session.Err).To(Say("audit"))
+			Expect(session.Err).To(Say("passwords"))
+		})
+	})
+
+	Describe("passwords", func() {
+		It("reports reused passwords and passwords that do not meet the policy without printing them", func() {
+			currentValues["/cf/db_password"] = `"type":"password","value":"Reused-Password-1234567890"`
+			currentValues["/cf/admin"] = `"type":"user","value":{"username":"admin","password":"Reused-Password-1234567890","password_hash":"hash"}`
+			currentValues["/cf/weak"] = `"type":"value","value":"weakpassword"`
+			currentValues["/cf/strong"] = `"type":"password","value":"Zk3mQ8vLx2Rt9WpN5bHc7YdF"`
+			currentValues["/cf/cert"] = `"type":"certificate","value":{"certificate":"cert"}`
+
+			session := runCommand("audit", "passwords", "-p", "/cf", "-j")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The audit found 1 group\\(s\\) of credentials sharing a password and 1 password\\(s\\) that do not meet the policy."))
+			Expect(string(session.Out.Contents())).NotTo(ContainSubstring("Password-1234567890"))
+			Expect(string(session.Out.Contents())).NotTo(ContainSubstring("weakpassword"))
+
+			var report struct {
+				Path    string `json:"path"`
+				Audited int    `json:"audited"`
+				Reused  []struct {
+					Hash  string   `json:"hash"`
+					Names []string `json:"names"`
+				} `json:"reused"`
+				Violations []map[string]interface{} `json:"violations"`
+			}
+			Expect(json.Unmarshal(session.Out.Contents(), &report)).To(Succeed())
+
+			Expect(report.Path).To(Equal("/cf"))
+			Expect(report.Audited).To(Equal(4))
+			Expect(report.Reused).To(HaveLen(1))
+			Expect(report.Reused[0].Hash).To(HaveLen(16))
+			Expect(report.Reused[0].Names).To(Equal([]string{"/cf/admin", "/cf/db_password"}))
+			Expect(report.Violations).To(ConsistOf(map[string]interface{}{
+				"name":              "/cf/weak",
+				"type":              "value",
+				"length":            float64(12),
+				"character_classes": float64(1),
+				"entropy":           56.4,
+				"violations":        []interface{}{"length", "character_classes", "entropy"},
+			}))
+		})
+
+		It("uses the configured policy", func() {
+			currentValues["/cf/weak"] = `"type":"value","value":"weakpassword"`
+
+			session := runCommand("audit", "passwords", "-p", "/cf", "--min-length", "12", "--min-character-classes", "1", "--min-entropy", "50")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("min_length: 12"))
+			Expect(session.Out).To(Say("audited: 1"))
+			Expect(session.Out).To(Say("reused: \\[\\]"))
+			Expect(session.Out).To(Say("violations: \\[\\]"))
+		})
+	})
+
+	Describe("age", func() {
+		BeforeEach(func() {
+			currentValues["/cf/prod/db_password"] = `"type":"password","value":"secret"`
+			currentValues["/cf/prod/admin"] = `"type":"user","value":{"username":"admin","password":"secret"}`
+			currentValues["/cf/dev/db_password"] = `"type":"password","value":"secret"`
+		})
+
+		It("lists credentials older than the maximum age grouped by type and path in a table", func() {
+			session := runCommand("audit", "age", "-p", "/cf", "--max-age", "90d")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say(`TYPE\s+PATH\s+NAME\s+UPDATED\s+AGE \(DAYS\)`))
+			Expect(session.Out).To(Say(`password\s+/cf/dev\s+/cf/dev/db_password\s+2017-01-01T00:00:00Z\s+\d+`))
+			Expect(session.Out).To(Say(`password\s+/cf/prod\s+/cf/prod/db_password`))
+			Expect(session.Out).To(Say(`user\s+/cf/prod\s+/cf/prod/admin`))
+		})
+
+		It("only includes credentials of the provided type in JSON", func() {
+			session := runCommand("audit", "age", "-p", "/cf", "--max-age", "90d", "--type", "user", "-j")
+
+			Eventually(session).Should(Exit(0))
+
+			var report struct {
+				Groups []struct {
+					Type        string                   `json:"type"`
+					Path        string                   `json:"path"`
+					Credentials []map[string]interface{} `json:"credentials"`
+				} `json:"groups"`
+			}
+			Expect(json.Unmarshal(session.Out.Contents(), &report)).To(Succeed())
+			Expect(report.Groups).To(HaveLen(1))
+			Expect(report.Groups[0].Type).To(Equal("user"))
+			Expect(report.Groups[0].Path).To(Equal("/cf/prod"))
+			Expect(report.Groups[0].Credentials).To(HaveLen(1))
+			Expect(report.Groups[0].Credentials[0]["name"]).To(Equal("/cf/prod/admin"))
+			Expect(report.Groups[0].Credentials[0]["age_days"]).To(BeNumerically(">", 90))
+		})
+
+		It("prints the table when it is requested with --output", func() {
+			session := runCommand("audit", "age", "-p", "/cf", "--max-age", "90d", "--output", "table")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say(`TYPE\s+PATH\s+NAME\s+UPDATED\s+AGE \(DAYS\)`))
+			Expect(session.Out).To(Say(`password\s+/cf/dev\s+/cf/dev/db_password`))
+		})
+
+		It("skips and reports credentials that can no longer be read", func() {
+			currentValues["/cf/prod/deleted"] = ""
+
+			session := runCommand("audit", "age", "-p", "/cf", "--max-age", "90d")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Err).To(Say("Skipping '/cf/prod/deleted': The request could not be completed"))
+			Expect(session.Out).To(Say(`user\s+/cf/prod\s+/cf/prod/admin`))
+			Expect(string(session.Out.Contents())).NotTo(ContainSubstring("/cf/prod/deleted"))
+		})
+
+		It("prints CSV", func() {
+			session := runCommand("audit", "age", "-p", "/cf", "--max-age", "90d", "--output", "csv")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("type,path,name,version_created_at,age_days\n"))
+			Expect(session.Out).To(Say("password,/cf/dev,/cf/dev/db_password,2017-01-01T00:00:00Z,\\d+\n"))
+		})
+
+		It("does not get credentials that were updated within the maximum age", func() {
+			session := runCommand("audit", "age", "-p", "/cf", "--max-age", "100000d", "-j")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out.Contents()).To(MatchJSON(`{"path":"/cf","max_age":"100000d","groups":[]}`))
+			for _, request := range server.ReceivedRequests() {
+				Expect(request.URL.Query().Get("name")).To(BeEmpty())
+			}
+		})
+
+		It("prints the report in the other output formats", func() {
+			session := runCommand("audit", "age", "-p", "/cf", "--max-age", "90d", "--type", "user", "--output", "yaml")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("path: /cf"))
+			Expect(session.Out).To(Say("- type: user"))
+			Expect(session.Out).To(Say("name: /cf/prod/admin"))
+		})
+
+		It("rejects invalid ages and output formats", func() {
+			session := runCommand("audit", "age", "-p", "/cf", "--max-age", "ninety days")
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The age 'ninety days' is not valid."))
+
+			session = runCommand("audit", "age", "-p", "/cf", "--max-age", "90d", "--output", "xml")
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The provided output format is not supported."))
+		})
+	})
+})
@@ -1,18 +1,20 @@
 package commands
 
 type BulkRegenerateCommand struct {
-	SignedBy   string `required:"yes" long:"signed-by" description:"Selects the credential whose children should recursively be regenerated"`
-	OutputJSON bool   `short:"j" long:"output-json" description:"Return response in JSON format"`
+	SignedBy string `required:"yes" long:"signed-by" description:"Selects the credential whose children should recursively be regenerated"`
+	OutputCommand
 	ClientCommand
 }
 
 func (c *BulkRegenerateCommand) Execute([]string) error {
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
 	credentials, err := c.client.BulkRegenerate(c.SignedBy)
 	if err != nil {
 		return err
 	}
 
-	printCredential(c.OutputJSON, credentials)
-
-	return nil
+	return c.printOutput(credentials)
 }
@@ -65,7 +65,7 @@ var _ = Describe("Bulk-regenerate", func() {
 
 			session := runCommand("bulk-regenerate", "--signed-by", "example-ca")
 
-			Eventually(session).Should(Exit(1))
+			Eventually(session).Should(Exit(6))
 			Expect(string(session.Err.Contents())).To(ContainSubstring("The certs could not be regenerated"))
 		})
 	})
@@ -6,19 +6,27 @@ import (
 )
 
 type CredhubCommand struct {
+	Agent          AgentCommand          `command:"agent"      description:"Serve cached credentials to the CLI over a local socket" long-description:"Run a credential agent that serves get and find requests over a Unix socket, which only the current user can connect to. The agent authenticates once and caches responses for the TTL. When CREDHUB_AGENT_SOCK is set to its socket path, the get, find, export and interpolate commands and the commands that change credentials send their requests to the agent. The agent flushes the cached responses for credentials that are changed, or all cached responses for other changes such as bulk regenerate. All other commands are sent to the CredHub server as usual."`
 	API            ApiCommand            `command:"api"        alias:"a" description:"Get or set the CredHub API target where commands are sent" long-description:"Get or set the CredHub API target where commands are sent. The api command without any flags will return the current target. If --ca-cert or --skip-tls-validation are provided, these preferences will be cached for future requests."`
+	Audit          AuditCommand          `command:"audit"      description:"Report on credentials for compliance audits" long-description:"Report on credentials for compliance audits. The passwords subcommand reports passwords that are reused or do not meet a policy. The age subcommand reports credentials that were not updated within a maximum age."`
 	Delete         DeleteCommand         `command:"delete"     alias:"d" description:"Delete a credential" long-description:"Delete a credential. This will delete all versions of the credential.\n\n More information: https://credhub-api.cfapps.io/#delete-credentials"`
+	Edit           EditCommand           `command:"edit"       description:"Edit a credential value in your editor" long-description:"Edit the current value of a credential in $EDITOR. The value is written as YAML to a temporary file that only you can read, which is overwritten and removed afterwards. The edited value is validated against the credential type. If it changed, a redacted summary of the changed fields is shown and, once confirmed, the value is set as a new version of the credential."`
 	Export         ExportCommand         `command:"export"     alias:"e" description:"Export all credentials" long-description:"Export all credentials.\n\n More information: https://credhub-api.cfapps.io/#export-credentials"`
 	Find           FindCommand           `command:"find"       alias:"f" description:"Find stored credential names or paths based on query parameters" long-description:"Find stored credential names or paths based on query parameters.\n\n More information: https://credhub-api.cfapps.io/#find-credentials"`
 	Generate       GenerateCommand       `command:"generate"   alias:"n" description:"Generate and set a credential value" long-description:"Set a credential with generated value(s). A type must be specified when generating a credential. The provided flags are used to set parameters for the credential that is generated, e.g. a certificate credential may use --common-name, --duration and --self-sign to generate an appropriate value. Supported credential types are prefixed in the flag description.\n\n More information: https://credhub-api.cfapps.io/#generate-credentials"`
 	Get            GetCommand            `command:"get"        alias:"g" description:"Get a credential value" long-description:"Get a credential value by name or ID.\n\n More information: https://credhub-api.cfapps.io/#get-credentials"`
 	Import         ImportCommand         `command:"import"     alias:"i" description:"Set multiple credential values" long-description:"Set multiple credential values from import file. File must be in yaml format containing a list of credentials under the key 'credentials'. Name, type and value are required for each credential in the list.\n\n More information: https://credhub-api.cfapps.io/#bulk-import"`
-	Interpolate    InterpolateCommand    `command:"interpolate" description:"Fill a template with values returned from CredHub" long-description:"Fill a template with values returned from CredHub.\n\nUses double-paren placeholders in the style of the bosh cli. Example:\n\n---\nsomething-stored-in-credhub: ((path/to/var))\nsomething-else: static value\n\nIn the above example, the whole value of the cred will be inserted.\nFor instance, if path/to/var is of type ssh, the output will have all the credential's fields, like this:\n\n---\nsomething-stored-in-credhub:\n  private_key: fake-private-key\n  public_key: fake-public-key\n  public_key_fingerprint: fake-fingerprint\nsome-other-key: static value\n\nIf you want just the password value, you'd need to use ((path/to/var.public_key)),\nwhich would only have the specified field, like this:\n\n---\nsomething-stored-in-credhub: fake-public-key\nsomething-else: static value\n\nIf the prefix flag is provided, the given prefix will be prepended\nto any credentials that do not start with the '/' character.\nExample:\n\n---\nsomething: ((/env-specific-path/path/to/var))\nsame-thing: ((path/to/var))\n\nWhen this example is used with the prefix flag 'env-specific-path', they will be evaluated to the same thing."`
-	Login          LoginCommand          `command:"login"      alias:"l" description:"Authenticate with CredHub" long-description:"Authenticate with CredHub. UAA password and client credential grants are supported. If client credentials exist in the environment, authentication will be performed automatically without the need to explicitly call this command."`
+	Interpolate    InterpolateCommand    `command:"interpolate" description:"Fill a template with values returned from CredHub" long-description:"Fill a template with values returned from CredHub.\n\nUses double-paren placeholders in the style of the bosh cli. Example:\n\n---\nsomething-stored-in-credhub: ((path/to/var))\nsomething-else: static value\n\nIn the above example, the whole value of the cred will be inserted.\nFor instance, if path/to/var is of type ssh, the output will have all the credential's fields, like this:\n\n---\nsomething-stored-in-credhub:\n  private_key: fake-private-key\n  public_key: fake-public-key\n  public_key_fingerprint: fake-fingerprint\nsome-other-key: static value\n\nIf you want just the password value, you'd need to use ((path/to/var.public_key)),\nwhich would only have the specified field, like this:\n\n---\nsomething-stored-in-credhub: fake-public-key\nsomething-else: static value\n\nIf the prefix flag is provided, the given prefix will be prepended\nto any credentials that do not start with the '/' character.\nExample:\n\n---\nsomething: ((/env-specific-path/path/to/var))\nsame-thing: ((path/to/var))\n\nWhen this example is used with the prefix flag 'env-specific-path', they will be evaluated to the same thing.\n\nThe prefix flag may be provided multiple times. The prefixes are tried in order, and the first credential found is used. For instance, with '-p /concourse/team/pipeline -p /concourse/team', ((foo)) resolves from /concourse/team/pipeline/foo and falls back to /concourse/team/foo. Use the explain flag to print the path each credential was resolved from.\n\nThe format flag selects how the file is read. 'yaml' and 'json' files are parsed and printed in the same format. With 'text', placeholders are replaced as raw strings anywhere in the file, e.g. in .env files, nginx configs or shell scripts. The escape flag can be used with 'text' to escape values as JSON string content ('json') or as single-quoted shell words ('shell')."`
+	Login          LoginCommand          `command:"login"      alias:"l" description:"Authenticate with CredHub" long-description:"Authenticate with CredHub. UAA password, client credential, SSO passcode and device authorization grants are supported. With --device, a code is shown that is entered on another device, e.g. in a browser on your workstation when logging in from a remote shell. If client credentials exist in the environment, authentication will be performed automatically without the need to explicitly call this command."`
 	Logout         LogoutCommand         `command:"logout"     alias:"o" description:"Discard authenticated user session" long-description:"Discard authenticated session. Refresh token revocation will be attempted for password grants."`
 	Regenerate     RegenerateCommand     `command:"regenerate" alias:"r" description:"Generate and set a credential value using the same attributes as the stored value" long-description:"Set a credential with a generated value using the same attributes as the stored value.\n\n More information: https://credhub-api.cfapps.io/#regenerate-credentials"`
+	Rollback       RollbackCommand       `command:"rollback" description:"Restore a previous credential value as the current version" long-description:"Restore a previous credential value as the current version. The value of the selected version is set as a new version of the credential with the same type. The version is selected by ID with --to-id, or by going back a number of versions with --steps (Default: 1). A summary of both versions, marking each field of the value as changed or unchanged without showing it, is shown and confirmation is requested unless --force is provided."`
+	Rotate         RotateCommand         `command:"rotate"     description:"Regenerate all credentials under a path that match filters" long-description:"Regenerate the credentials under a path, e.g. on a schedule to enforce rotation. Credentials can be selected by type and by the age of their current version. Credentials are regenerated with the same attributes as their current value, several at the same time. Certificates are regenerated after the CAs that sign them. The report includes the old and new version ID of each credential and the errors of credentials that could not be regenerated. With --dry-run, the credentials that would be rotated are reported without regenerating them."`
+	Completion     CompletionCommand     `command:"completion" description:"Generate a shell completion script" long-description:"Generate a completion script for bash, zsh or fish. The script completes commands, flags, and the names and paths of credentials on the targeted server. For example, add 'source <(credhub completion bash)' to your ~/.bashrc. Credential names are cached for a short time so completion stays fast."`
 	BulkRegenerate BulkRegenerateCommand `command:"bulk-regenerate" description:"Recursively regenerate all certificates signed by the provided certificate" long-description:"Recursively regenerate all certificates signed by the provided certificate\n\n More information: https://credhub-api.cfapps.io/#certificate-signed-by-a-ca"`
 	Set            SetCommand            `command:"set"        alias:"s" description:"Set a credential with a provided value" long-description:"Set a credential with provided value(s). A type must be specified when setting a credential. The provided flags are used to set specific values of a credential, e.g. a certificate credential may use --root, --certificate and --private to set each value. Supported credential types are prefixed in the flag description.\n\n More information: https://credhub-api.cfapps.io/#set-credentials"`
+	Watch          WatchCommand          `command:"watch"      description:"Watch credentials and run a command when they change" long-description:"Watch credentials and run a command when they change. The current version of each credential is checked every interval. When a new version is found, the value is written to the write directory, if provided, and the exec command is run with CREDHUB_NAME, CREDHUB_VERSION_ID, CREDHUB_PREVIOUS_VERSION_ID and CREDHUB_FILE set in its environment. The provided path is listed again on every check, so that credentials added to it are watched too. Failed checks of a credential are retried with increasing delays, while other credentials are still checked every interval."`
+	Whoami         WhoamiCommand         `command:"whoami"     description:"Show the identity of the current authentication token" long-description:"Show the user, client, scopes, issuer and expiry of the current access token, and the API and auth server it is used with. The claims are decoded from the token without contacting the auth server. With --verify, the user is confirmed with the userinfo endpoint of the auth server, or the client with its check_token endpoint."`
 	Curl           CurlCommand           `command:"curl"       description:"Make an arbitrary request to the targeted CredHub server." long-description:"Make an arbitrary request to the targeted CredHub server"`
 
 	Version func() `long:"version" description:"Version of CLI and targeted CredHub API"`
@@ -266,7 +266,7 @@ func ItRequiresAuthentication(args ...string) {
 
 		session := runCommand(args...)
 
-		Eventually(session).Should(Exit(1))
+		Eventually(session).Should(Exit(3))
 		Expect(session.Err).To(Say("You are not currently authenticated. Please log in to continue."))
 	})
 }
@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/credhub-cli/config"
+	"code.cloudfoundry.org/credhub-cli/credhub"
+	"code.cloudfoundry.org/credhub-cli/errors"
+	"github.com/jessevdk/go-flags"
+)
+
+// CompletionCacheTTL is how long credential names found for completion are reused
+var CompletionCacheTTL = 30 * time.Second
+
+type CompletionCommand struct {
+	Args CompletionPositionalArgs `positional-args:"yes"`
+}
+
+type CompletionPositionalArgs struct {
+	Shell string `positional-arg-name:"SHELL" required:"yes" description:"Shell to generate the completion script for: bash, zsh or fish"`
+}
+
+func (c *CompletionCommand) Execute([]string) error {
+	script, ok := completionScripts[c.Args.Shell]
+	if !ok {
+		return errors.NewUnknownShellError(c.Args.Shell)
+	}
+
+	fmt.Print(script)
+	return nil
+}
+
+var completionScripts = map[string]string{
+	"bash": `_credhub() {
+    local args=("${COMP_WORDS[@]:1:$COMP_CWORD}")
+    local IFS=$'\n'
+    COMPREPLY=($(GO_FLAGS_COMPLETION=1 ${COMP_WORDS[0]} "${args[@]}"))
+    return 0
+}
+complete -o default -F _credhub credhub
+`,
+	"zsh": `#compdef credhub
+_credhub() {
+    local -a completions
+    completions=("${(@f)$(GO_FLAGS_COMPLETION=1 ${words[1]} "${(@)words[2,$CURRENT]}")}")
+    compadd -a completions
+}
+compdef _credhub credhub
+`,
+	"fish": `function __credhub_complete
+    set -l args (commandline -opc)[2..-1] (commandline -ct)
+    GO_FLAGS_COMPLETION=1 credhub $args
+end
+complete -c credhub -f -a '(__credhub_complete)'
+`,
+}
+
+// CredentialName is the name of a credential, completed with the names of
+// credentials on the targeted server
+type CredentialName string
+
+// CredentialPath is a path of credentials, completed with the paths of
+// credentials on the targeted server
+type CredentialPath string
+
+func (n *CredentialName) Complete(match string) []flags.Completion {
+	prefix, names := completionCandidates(match)
+
+	var items []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			items = append(items, name)
+		}
+	}
+
+	return completions(match, items)
+}
+
+func (p *CredentialPath) Complete(match string) []flags.Completion {
+	prefix, names := completionCandidates(match)
+
+	found := map[string]bool{}
+	var items []string
+	for _, name := range names {
+		for i := len(prefix); i < len(name); i++ {
+			if name[i] != '/' {
+				continue
+			}
+			dir := name[:i+1]
+			if strings.HasPrefix(dir, prefix) && !found[dir] {
+				found[dir] = true
+				items = append(items, dir)
+			}
+		}
+	}
+
+	return completions(match, items)
+}
+
+// completionCandidates returns the absolute form of match and the names of
+// credentials within its parent path
+func completionCandidates(match string) (string, []string) {
+	prefix := "/" + strings.TrimPrefix(match, "/")
+	dir := prefix[:strings.LastIndex(prefix, "/")+1]
+
+	names, err := findNamesForCompletion(dir)
+	if err != nil {
+		return prefix, nil
+	}
+
+	return prefix, names
+}
+
+// completions returns items as completions, without the leading slash if
+// match was given without one
+func completions(match string, items []string) []flags.Completion {
+	sort.Strings(items)
+
+	result := make([]flags.Completion, len(items))
+	for i, item := range items {
+		if !strings.HasPrefix(match, "/") {
+			item = strings.TrimPrefix(item, "/")
+		}
+		result[i].Item = item
+	}
+
+	return result
+}
+
+type completionCacheEntry struct {
+	Names     []string  `json:"names"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+func completionCachePath() string {
+	return path.Join(config.ConfigDir(), "completion_cache.json")
+}
+
+// findNamesForCompletion returns the names of the credentials within dir. Names
+// are cached per server and path for CompletionCacheTTL, so that repeated tab
+// presses do not each send a request.
+func findNamesForCompletion(dir string) ([]string, error) {
+	cfg := config.ReadConfig()
+	key := cfg.ApiURL + " " + dir
+
+	if entry, ok := readCompletionCache()[key]; ok && time.Since(entry.FetchedAt) < CompletionCacheTTL {
+		return entry.Names, nil
+	}
+
+	client, err := newCompletionClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := client.FindByPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(results.Credentials))
+	for _, cred := range results.Credentials {
+		names = append(names, "/"+strings.TrimPrefix(cred.Name, "/"))
+	}
+
+	config.WithConfigLock(func() error {
+		cache := readCompletionCache()
+		for k, entry := range cache {
+			if time.Since(entry.FetchedAt) >= CompletionCacheTTL {
+				delete(cache, k)
+			}
+		}
+		cache[key] = completionCacheEntry{Names: names, FetchedAt: time.Now()}
+
+		data, err := json.Marshal(cache)
+		if err != nil {
+			return err
+		}
+		os.MkdirAll(config.ConfigDir(), 0700)
+		return ioutil.WriteFile(completionCachePath(), data, 0600)
+	})
+
+	return names, nil
+}
+
+// newCompletionClient builds a client from cfg as it is saved. Unlike the
+// client of other commands, it never requests the auth server URL from the
+// server or writes the config, as completion runs on each tab press. It
+// returns an error when the user is not logged in.
+func newCompletionClient(cfg config.Config) (*credhub.CredHub, error) {
+	if err := config.ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.AuthURL == "" {
+		return nil, errors.NewRevokedTokenError()
+	}
+
+	clientId, clientSecret, usingClientCredentials := authClient(cfg)
+	return newCredhubClient(&cfg, clientId, clientSecret, usingClientCredentials)
+}
+
+func readCompletionCache() map[string]completionCacheEntry {
+	cache := map[string]completionCacheEntry{}
+	if data, err := ioutil.ReadFile(completionCachePath()); err == nil {
+		json.Unmarshal(data, &cache)
+	}
+	return cache
+}
@@ -0,0 +1,120 @@
+package commands_test
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/credhub-cli/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Completion", func() {
+	BeforeEach(func() {
+		login()
+	})
+
+	It("prints a completion script for each supported shell", func() {
+		for _, shell := range []string{"bash", "zsh", "fish"} {
+			session := runCommand("completion", shell)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("GO_FLAGS_COMPLETION=1"))
+		}
+	})
+
+	It("fails for an unknown shell", func() {
+		session := runCommand("completion", "tcsh")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The shell 'tcsh' is not supported. Valid shells include 'bash', 'zsh' and 'fish'."))
+	})
+
+	Describe("completing credential names and paths", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				CombineHandlers(
+					VerifyRequest("GET", "/api/v1/data", "path=/deploy/"),
+					RespondWith(http.StatusOK, `{"credentials":[
+						{"name":"/deploy/db/password","version_created_at":"2016-09-06T23:26:58Z"},
+						{"name":"/deploy/db/user","version_created_at":"2016-09-06T23:26:58Z"},
+						{"name":"/deploy/web/cert","version_created_at":"2016-09-06T23:26:58Z"}
+					]}`),
+				),
+			)
+		})
+
+		It("completes names of credentials within the path being typed", func() {
+			session := runCommandWithEnv([]string{"GO_FLAGS_COMPLETION=1"}, "get", "-n", "/deploy/d")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal("/deploy/db/password\n/deploy/db/user\n"))
+		})
+
+		It("keeps names relative when they are typed without a leading slash", func() {
+			session := runCommandWithEnv([]string{"GO_FLAGS_COMPLETION=1"}, "delete", "--name=deploy/w")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal("--name=deploy/web/cert\n"))
+		})
+
+		It("completes paths", func() {
+			session := runCommandWithEnv([]string{"GO_FLAGS_COMPLETION=1"}, "find", "-p", "/deploy/")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal("/deploy/db/\n/deploy/web/\n"))
+		})
+
+		It("reuses names found by a recent completion", func() {
+			runCommandWithEnv([]string{"GO_FLAGS_COMPLETION=1"}, "get", "-n", "/deploy/d")
+			session := runCommandWithEnv([]string{"GO_FLAGS_COMPLETION=1"}, "get", "-n", "/deploy/w")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal("/deploy/web/cert\n"))
+
+			findRequests := 0
+			for _, request := range server.ReceivedRequests() {
+				if request.URL.Path == "/api/v1/data" {
+					findRequests++
+				}
+			}
+			Expect(findRequests).To(Equal(1))
+		})
+
+		It("completes nothing and prints nothing when not logged in", func() {
+			cfg := config.ReadConfig()
+			cfg.AccessToken = "revoked"
+			cfg.RefreshToken = "revoked"
+			Expect(config.WriteConfig(cfg)).To(Succeed())
+			requests := len(server.ReceivedRequests())
+
+			session := runCommandWithEnv([]string{"GO_FLAGS_COMPLETION=1"}, "get", "-n", "/deploy/d")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out.Contents()).To(BeEmpty())
+			Expect(session.Err.Contents()).To(BeEmpty())
+			Expect(server.ReceivedRequests()).To(HaveLen(requests))
+		})
+
+		It("does not request the auth server URL or write the config", func() {
+			cfg := config.ReadConfig()
+			cfg.AuthURL = ""
+			Expect(config.WriteConfig(cfg)).To(Succeed())
+			before, err := ioutil.ReadFile(config.ConfigPath())
+			Expect(err).NotTo(HaveOccurred())
+			requests := len(server.ReceivedRequests())
+
+			session := runCommandWithEnv([]string{"GO_FLAGS_COMPLETION=1"}, "get", "-n", "/deploy/d")
+
+			Eventually(session).Should(Exit(0))
+			Expect(server.ReceivedRequests()).To(HaveLen(requests))
+			after, err := ioutil.ReadFile(config.ConfigPath())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(Equal(before))
+		})
+	})
+})
@@ -5,14 +5,29 @@ import (
 )
 
 type DeleteCommand struct {
-	CredentialIdentifier string `short:"n" long:"name" required:"yes" description:"Name of the credential to delete"`
+	CredentialIdentifier CredentialName `short:"n" long:"name" required:"yes" description:"Name of the credential to delete"`
+	OutputCommand
 	ClientCommand
 }
 
+type deleteSummary struct {
+	Name    string `json:"name" yaml:"name"`
+	Deleted bool   `json:"deleted" yaml:"deleted"`
+}
+
 func (c *DeleteCommand) Execute([]string) error {
-	if err := c.client.Delete(c.CredentialIdentifier); err != nil {
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
+	if err := c.client.Delete(string(c.CredentialIdentifier)); err != nil {
 		return err
 	}
+
+	if !c.isDefaultOutput() {
+		return c.printOutput(deleteSummary{Name: string(c.CredentialIdentifier), Deleted: true})
+	}
+
 	fmt.Println("Credential successfully deleted")
 	return nil
 }
@@ -43,6 +43,20 @@ var _ = Describe("Delete", func() {
 		Eventually(session.Out).Should(Say("Credential successfully deleted"))
 	})
 
+	It("can print a summary as JSON", func() {
+		server.RouteToHandler("DELETE", "/api/v1/data",
+			CombineHandlers(
+				VerifyRequest("DELETE", "/api/v1/data", "name=my-secret"),
+				RespondWith(http.StatusOK, ""),
+			),
+		)
+
+		session := runCommand("delete", "-n", "my-secret", "--output", "json")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out.Contents()).To(MatchJSON(`{"name":"my-secret","deleted":true}`))
+	})
+
 	Describe("Errors", func() {
 		It("prints an error when the network request fails", func() {
 			cfg := config.ReadConfig()
@@ -51,7 +65,7 @@ var _ = Describe("Delete", func() {
 
 			session := runCommand("delete", "-n", "my-secret")
 
-			Eventually(session).Should(Exit(1))
+			Eventually(session).Should(Exit(7))
 			Eventually(string(session.Err.Contents())).Should(ContainSubstring("Delete mashed://potatoes/api/v1/data?name=my-secret: unsupported protocol scheme \"mashed\""))
 		})
 
@@ -0,0 +1,254 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/credhub-cli/errors"
+	"gopkg.in/yaml.v2"
+)
+
+type EditCommand struct {
+	CredentialIdentifier CredentialName `short:"n" long:"name" required:"yes" description:"Name of the credential to edit"`
+	Force                bool           `long:"force" description:"Save the changes without asking for confirmation"`
+	OutputCommand
+	ClientCommand
+}
+
+// editableFields are the fields of each credential type with a structured
+// value. Types that are not listed have a string value.
+var editableFields = map[string][]string{
+	"json":        nil,
+	"user":        {"username", "password"},
+	"certificate": {"ca", "ca_name", "certificate", "private_key"},
+	"ssh":         {"public_key", "private_key"},
+	"rsa":         {"public_key", "private_key"},
+}
+
+func (c *EditCommand) Execute([]string) error {
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
+	current, err := c.client.GetLatestVersion(string(c.CredentialIdentifier))
+	if err != nil {
+		return err
+	}
+
+	original, err := toEditableValue(settableValue(current))
+	if err != nil {
+		return err
+	}
+
+	contents, err := yaml.Marshal(original)
+	if err != nil {
+		return err
+	}
+
+	edited, err := editInEditor(contents)
+	if err != nil {
+		return err
+	}
+
+	var parsed interface{}
+	if err := yaml.Unmarshal(edited, &parsed); err != nil {
+		return errors.NewEditInvalidYAMLError(err)
+	}
+
+	updated, err := toEditableValue(toJSONCompatible(parsed))
+	if err != nil {
+		return err
+	}
+
+	if err := validateEditedValue(current.Type, updated); err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(original, updated) {
+		fmt.Println("No changes were made.")
+		return nil
+	}
+
+	fmt.Println(redactedDiff(original, updated))
+
+	if !c.Force {
+		var answer string
+		promptForInput("Are you sure you want to save this credential? [y/N]: ", &answer)
+		if answer != "y" && answer != "yes" {
+			return errors.NewEditAbortedError()
+		}
+	}
+
+	credential, err := c.client.SetCredential(current.Name, current.Type, updated)
+	if err != nil {
+		return err
+	}
+
+	credential.Value = "<redacted>"
+	return c.printOutput(credential)
+}
+
+// toEditableValue converts v into the maps, slices and scalars produced by
+// decoding its JSON representation. Numbers are decoded as float64 rather than
+// json.Number, so that they are written to the editor as YAML numbers.
+func toEditableValue(v interface{}) (interface{}, error) {
+	s, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	err = json.Unmarshal(s, &data)
+	return data, err
+}
+
+// editInEditor writes contents to a temporary file that only the current user
+// can read, opens it in $EDITOR and returns the saved contents. The file is
+// overwritten before it is removed.
+func editInEditor(contents []byte) ([]byte, error) {
+	file, err := ioutil.TempFile("", "credhub-edit-*.yml")
+	if err != nil {
+		return nil, err
+	}
+	defer shredFile(file.Name())
+
+	if err := file.Chmod(0600); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Write(contents); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.NewEditorFailedError(err)
+	}
+
+	return ioutil.ReadFile(file.Name())
+}
+
+// shredFile overwrites the file in place with zeros before removing it, so
+// the plaintext is not left behind in the blocks the file occupied
+func shredFile(name string) {
+	defer os.Remove(name)
+
+	file, err := os.OpenFile(name, os.O_WRONLY, 0)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return
+	}
+	if _, err := file.Write(make([]byte, info.Size())); err != nil {
+		return
+	}
+	file.Sync()
+}
+
+func validateEditedValue(credentialType string, value interface{}) error {
+	fields, structured := editableFields[credentialType]
+	if !structured {
+		if _, ok := value.(string); !ok {
+			return errors.NewEditInvalidValueError(credentialType, "a string")
+		}
+		return nil
+	}
+
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.NewEditInvalidValueError(credentialType, "a mapping")
+	}
+
+	if fields == nil {
+		return nil
+	}
+
+	for key := range m {
+		allowed := false
+		for _, field := range fields {
+			allowed = allowed || key == field
+		}
+		if !allowed {
+			return errors.NewEditUnknownFieldError(credentialType, key)
+		}
+	}
+
+	return nil
+}
+
+// redactedDiff describes the changed fields of a credential value without
+// showing their values
+func redactedDiff(original, updated interface{}) string {
+	changes := fieldChanges(original, updated)
+
+	sorted := make([]string, 0, len(changes))
+	for k := range changes {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	markers := map[string]string{"added": "+", "removed": "-", "changed": "~"}
+
+	var lines []string
+	for _, k := range sorted {
+		if marker, ok := markers[changes[k]]; ok {
+			lines = append(lines, fmt.Sprintf("%s %s: <redacted>", marker, k))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// fieldChanges returns whether each field of a credential value was added,
+// removed, changed or left unchanged. Values that are not mappings are
+// compared as a single value field.
+func fieldChanges(original, updated interface{}) map[string]string {
+	before, beforeIsMap := original.(map[string]interface{})
+	after, afterIsMap := updated.(map[string]interface{})
+	if !beforeIsMap || !afterIsMap {
+		before = map[string]interface{}{"value": original}
+		after = map[string]interface{}{"value": updated}
+	}
+
+	changes := map[string]string{}
+	for k, oldValue := range before {
+		newValue, inAfter := after[k]
+		switch {
+		case !inAfter:
+			changes[k] = "removed"
+		case reflect.DeepEqual(oldValue, newValue):
+			changes[k] = "unchanged"
+		default:
+			changes[k] = "changed"
+		}
+	}
+	for k := range after {
+		if _, inBefore := before[k]; !inBefore {
+			changes[k] = "added"
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,151 @@
+package commands_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+const EDIT_USER_RESPONSE_JSON = `{"data":[{"type":"user","id":"` + UUID + `","name":"/my-user","version_created_at":"2017-02-01T12:00:00Z","value":{"username":"my-user","password":"old-password","password_hash":"old-hash"}}]}`
+
+const EDIT_SET_RESPONSE_JSON = `{"type":"user","id":"9b3c2d4e-1686-4c8d-80eb-5daa866f9f86","name":"/my-user","version_created_at":"2017-03-01T12:00:00Z","value":{"username":"my-user","password":"new-password","password_hash":"new-hash"}}`
+
+var _ = Describe("Edit", func() {
+	var editorScript string
+
+	useEditor := func(script string) {
+		editorScript = filepath.Join(homeDir, "editor.sh")
+		Expect(ioutil.WriteFile(editorScript, []byte("#!/bin/sh\n"+script+"\n"), 0700)).To(Succeed())
+		os.Setenv("EDITOR", editorScript)
+	}
+
+	BeforeEach(func() {
+		login()
+
+		server.RouteToHandler("GET", "/api/v1/data",
+			CombineHandlers(
+				VerifyRequest("GET", "/api/v1/data", "current=true&name=/my-user"),
+				RespondWith(http.StatusOK, EDIT_USER_RESPONSE_JSON),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("EDITOR")
+	})
+
+	ItRequiresAuthentication("edit", "-n", "test-credential")
+	ItRequiresAnAPIToBeSet("edit", "-n", "test-credential")
+
+	Describe("Help", func() {
+		ItBehavesLikeHelp("edit", "edit", func(session *Session) {
+			Expect(session.Err).To(Say("edit"))
+			Expect(session.Err).To(Say("name"))
+		})
+	})
+
+	It("sets the edited value as a new version after showing a redacted diff", func() {
+		useEditor(`sed -i 's/old-password/new-password/' "$1"`)
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest("PUT", "/api/v1/data"),
+				VerifyJSON(`{"name":"/my-user","type":"user","value":{"username":"my-user","password":"new-password"}}`),
+				RespondWith(http.StatusOK, EDIT_SET_RESPONSE_JSON),
+			),
+		)
+
+		session := runCommandWithStdin(bytes.NewBufferString("y\n"), "edit", "-n", "/my-user")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("~ password: <redacted>"))
+		Expect(session.Out).To(Say(`Are you sure you want to save this credential\? \[y/N\]:`))
+		Expect(session.Out).To(Say("id: 9b3c2d4e-1686-4c8d-80eb-5daa866f9f86"))
+		Expect(session.Out).To(Say("value: <redacted>"))
+		Expect(session.Out.Contents()).NotTo(ContainSubstring("old-password"))
+		Expect(session.Out.Contents()).NotTo(ContainSubstring("new-password"))
+	})
+
+	It("opens a temporary file that only the user can read and removes it afterwards", func() {
+		useEditor(`echo "$1" > "` + filepath.Join(homeDir, "edited-file") + `"; stat -c %a "$1" > "` + filepath.Join(homeDir, "edited-mode") + `"`)
+
+		session := runCommand("edit", "-n", "/my-user")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("No changes were made."))
+
+		mode, err := ioutil.ReadFile(filepath.Join(homeDir, "edited-mode"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.TrimSpace(string(mode))).To(Equal("600"))
+
+		file, err := ioutil.ReadFile(filepath.Join(homeDir, "edited-file"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.TrimSpace(string(file))).NotTo(BeAnExistingFile())
+
+		for _, request := range server.ReceivedRequests() {
+			Expect(request.Method).NotTo(Equal("PUT"))
+		}
+	})
+
+	It("does not set anything when the changes are not confirmed", func() {
+		useEditor(`sed -i 's/old-password/new-password/' "$1"`)
+
+		session := runCommandWithStdin(bytes.NewBufferString("n\n"), "edit", "-n", "/my-user")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("Edit aborted."))
+		for _, request := range server.ReceivedRequests() {
+			Expect(request.Method).NotTo(Equal("PUT"))
+		}
+	})
+
+	It("does not ask for confirmation with --force", func() {
+		useEditor(`sed -i 's/old-password/new-password/' "$1"`)
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest("PUT", "/api/v1/data"),
+				RespondWith(http.StatusOK, EDIT_SET_RESPONSE_JSON),
+			),
+		)
+
+		session := runCommand("edit", "-n", "/my-user", "--force")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).NotTo(Say("Are you sure"))
+	})
+
+	It("rejects fields that are not valid for the credential type", func() {
+		useEditor(`echo "email: someone@example.com" >> "$1"`)
+
+		session := runCommand("edit", "-n", "/my-user", "--force")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The field 'email' is not valid for a user credential. The credential was not changed."))
+	})
+
+	It("rejects values that are not valid YAML", func() {
+		useEditor(`echo "password: [" > "$1"`)
+
+		session := runCommand("edit", "-n", "/my-user", "--force")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The edited value is not valid YAML"))
+	})
+
+	It("does not set anything when the editor fails", func() {
+		useEditor(`exit 3`)
+
+		session := runCommand("edit", "-n", "/my-user", "--force")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The editor exited with an error: exit status 3. The credential was not changed."))
+	})
+})
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	goerrors "errors"
+
+	"code.cloudfoundry.org/credhub-cli/credhub"
+)
+
+// Exit codes of the CLI, so that scripts can tell kinds of failures apart.
+// All other failures, including invalid flags, exit with ExitCodeError.
+const (
+	ExitCodeError      = 1
+	ExitCodeNotFound   = 2
+	ExitCodeAuth       = 3
+	ExitCodeForbidden  = 4
+	ExitCodeConflict   = 5
+	ExitCodeValidation = 6
+	ExitCodeNetwork    = 7
+)
+
+// ExitCode returns the exit code of the CLI for the error
+func ExitCode(err error) int {
+	var (
+		notFound     *credhub.NotFoundError
+		unauthorized *credhub.UnauthorizedError
+		authErr      *credhub.AuthError
+		forbidden    *credhub.ForbiddenError
+		conflict     *credhub.ConflictError
+		validation   *credhub.ValidationError
+		networkErr   *credhub.NetworkError
+	)
+
+	switch {
+	case err == nil:
+		return 0
+	case goerrors.As(err, &notFound):
+		return ExitCodeNotFound
+	case goerrors.As(err, &unauthorized), goerrors.As(err, &authErr):
+		return ExitCodeAuth
+	case goerrors.As(err, &forbidden):
+		return ExitCodeForbidden
+	case goerrors.As(err, &conflict):
+		return ExitCodeConflict
+	case goerrors.As(err, &validation):
+		return ExitCodeValidation
+	case goerrors.As(err, &networkErr):
+		return ExitCodeNetwork
+	default:
+		return ExitCodeError
+	}
+}
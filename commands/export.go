@@ -10,12 +10,12 @@ import (
 )
 
 type ExportCommand struct {
-	Path string `short:"p" long:"path" description:"Path of credentials to export" required:"false"`
-	File string `short:"f" long:"file" description:"File in which to write credentials" required:"false"`
+	Path CredentialPath `short:"p" long:"path" description:"Path of credentials to export" required:"false"`
+	File string         `short:"f" long:"file" description:"File in which to write credentials" required:"false"`
 }
 
 func (cmd ExportCommand) Execute([]string) error {
-	allCredentials, err := getAllCredentialsForPath(cmd.Path)
+	allCredentials, err := getAllCredentialsForPath(string(cmd.Path))
 
 	if err != nil {
 		return err
@@ -156,7 +156,7 @@ var _ = Describe("Export", func() {
 
 			session := runCommand("export")
 
-			Eventually(session).Should(Exit(1))
+			Eventually(session).Should(Exit(7))
 			Eventually(string(session.Err.Contents())).Should(ContainSubstring("Get mashed://potatoes/api/v1/data?path=: unsupported protocol scheme \"mashed\""))
 		})
 
@@ -1,37 +1,293 @@
 package commands
 
 import (
+	goerrors "errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/credhub-cli/credhub"
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
 	"code.cloudfoundry.org/credhub-cli/errors"
 )
 
+// latestVersionConcurrency is the number of credentials fetched at the same
+// time, e.g. to filter find results by type
+const latestVersionConcurrency = 10
+
 type FindCommand struct {
-	PartialCredentialIdentifier string `short:"n" long:"name-like" description:"Find credentials whose name contains the query string"`
-	PathIdentifier              string `short:"p" long:"path" description:"Find credentials that exist under the provided path"`
-	OutputJSON                  bool   `short:"j" long:"output-json" description:"Return response in JSON format"`
+	PartialCredentialIdentifier string         `short:"n" long:"name-like" description:"Find credentials whose name contains the query string"`
+	PathIdentifier              CredentialPath `short:"p" long:"path" description:"Find credentials that exist under the provided path"`
+	Type                        string         `short:"t" long:"type" description:"Only include credentials of the provided type, e.g. 'certificate'"`
+	UpdatedBefore               string         `long:"updated-before" description:"Only include credentials last updated before the provided time, e.g. '2017-01-01' or '2017-01-01T12:00:00Z'"`
+	UpdatedAfter                string         `long:"updated-after" description:"Only include credentials last updated after the provided time, e.g. '2017-01-01' or '2017-01-01T12:00:00Z'"`
+	Glob                        string         `long:"glob" description:"Only include credentials whose name matches the provided glob, e.g. '/cf/*/db_*'. A '*' does not match '/'"`
+	Regex                       string         `long:"regex" description:"Only include credentials whose name matches the provided regular expression"`
+	Sort                        string         `long:"sort" description:"Sort credentials by 'name', or by 'updated' with the most recently updated first"`
+	Count                       bool           `long:"count" description:"Return only the number of credentials found"`
+	OutputCommand
 	ClientCommand
 }
 
+type findCount struct {
+	Count int `json:"count" yaml:"count"`
+}
+
 func (c *FindCommand) Execute([]string) error {
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
 
+	filter, err := c.filter()
+	if err != nil {
+		return err
+	}
+
+	var results credentials.FindResults
 	if c.PartialCredentialIdentifier != "" {
-		results, err := c.client.FindByPartialName(c.PartialCredentialIdentifier)
+		results, err = c.client.FindByPartialName(c.PartialCredentialIdentifier)
+	} else {
+		results, err = c.client.FindByPath(string(c.PathIdentifier))
+	}
+	if err != nil {
+		return err
+	}
+
+	results.Credentials, err = c.apply(filter, results.Credentials)
+	if err != nil {
+		return err
+	}
+
+	if c.Count {
+		if c.isDefaultOutput() {
+			fmt.Println(len(results.Credentials))
+			return nil
+		}
+		return c.printOutput(findCount{Count: len(results.Credentials)})
+	}
+
+	if c.PartialCredentialIdentifier != "" && len(results.Credentials) == 0 {
+		return errors.NewNoMatchingCredentialsFoundError()
+	}
+
+	return c.printOutput(results)
+}
+
+// findFilter selects find results by their name and when they were last updated
+type findFilter struct {
+	before, after time.Time
+	glob          string
+	regex         *regexp.Regexp
+}
+
+func (c *FindCommand) filter() (findFilter, error) {
+	var (
+		filter findFilter
+		err    error
+	)
+
+	if c.UpdatedBefore != "" {
+		if filter.before, err = parseFindTime(c.UpdatedBefore); err != nil {
+			return filter, err
+		}
+	}
+	if c.UpdatedAfter != "" {
+		if filter.after, err = parseFindTime(c.UpdatedAfter); err != nil {
+			return filter, err
+		}
+	}
+
+	if c.Glob != "" {
+		if _, err := path.Match(c.Glob, ""); err != nil {
+			return filter, errors.NewInvalidFindGlobError(c.Glob)
+		}
+		filter.glob = c.Glob
+	}
+
+	if c.Regex != "" {
+		if filter.regex, err = regexp.Compile(c.Regex); err != nil {
+			return filter, errors.NewInvalidFindRegexError(err)
+		}
+	}
+
+	switch c.Sort {
+	case "", "name", "updated":
+	default:
+		return filter, errors.NewInvalidFindSortError()
+	}
+
+	return filter, nil
+}
+
+func parseFindTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.NewInvalidFindTimeError(value)
+}
+
+func (f findFilter) matches(cred credentials.Base) bool {
+	if !f.before.IsZero() || !f.after.IsZero() {
+		updated, err := time.Parse(time.RFC3339, cred.VersionCreatedAt)
 		if err != nil {
-			return err
+			return false
 		}
+		if !f.before.IsZero() && !updated.Before(f.before) {
+			return false
+		}
+		if !f.after.IsZero() && !updated.After(f.after) {
+			return false
+		}
+	}
 
-		if len(results.Credentials) == 0 {
-			return errors.NewNoMatchingCredentialsFoundError()
+	if f.glob != "" {
+		name := strings.TrimPrefix(cred.Name, "/")
+		if strings.HasPrefix(f.glob, "/") {
+			name = "/" + name
 		}
+		if matched, _ := path.Match(f.glob, name); !matched {
+			return false
+		}
+	}
 
-		printCredential(c.OutputJSON, results)
-	} else {
-		output, err := c.client.FindByPath(c.PathIdentifier)
+	if f.regex != nil && !f.regex.MatchString(cred.Name) {
+		return false
+	}
+
+	return true
+}
+
+// apply filters and sorts the results. Filtering by type gets the metadata of
+// the current version of each credential that matches the other filters.
+func (c *FindCommand) apply(filter findFilter, creds []credentials.Base) ([]credentials.Base, error) {
+	matching := creds[:0]
+	for _, cred := range creds {
+		if filter.matches(cred) {
+			matching = append(matching, cred)
+		}
+	}
+
+	if c.Type != "" {
+		var err error
+		if matching, err = c.filterByType(matching); err != nil {
+			return nil, err
+		}
+	}
+
+	switch c.Sort {
+	case "name":
+		sort.SliceStable(matching, func(i, j int) bool {
+			return matching[i].Name < matching[j].Name
+		})
+	case "updated":
+		updated := map[string]time.Time{}
+		for _, cred := range matching {
+			updated[cred.Name], _ = time.Parse(time.RFC3339, cred.VersionCreatedAt)
+		}
+		sort.SliceStable(matching, func(i, j int) bool {
+			return updated[matching[i].Name].After(updated[matching[j].Name])
+		})
+	}
+
+	return matching, nil
+}
+
+func (c *FindCommand) filterByType(creds []credentials.Base) ([]credentials.Base, error) {
+	names := make([]string, len(creds))
+	for i, cred := range creds {
+		names[i] = cred.Name
+	}
+
+	metadata, err := getLatestMetadata(c.client, names)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := creds[:0]
+	for _, cred := range creds {
+		if m, ok := metadata[cred.Name]; ok && strings.EqualFold(m.Type, c.Type) {
+			matching = append(matching, cred)
+		}
+	}
+
+	return matching, nil
+}
+
+// getLatestVersions gets the current version of each credential, with up to
+// latestVersionConcurrency requests at the same time
+func getLatestVersions(client *credhub.CredHub, names []string) ([]credentials.Credential, error) {
+	creds := make([]credentials.Credential, len(names))
+	errs := make([]error, len(names))
+
+	forEachConcurrently(len(names), latestVersionConcurrency, func(i int) {
+		creds[i], errs[i] = client.GetLatestVersion(names[i])
+	})
+
+	for _, err := range errs {
 		if err != nil {
-			return err
+			return nil, err
 		}
+	}
+
+	return creds, nil
+}
+
+// getLatestMetadata gets the metadata of the current version of each named
+// credential, e.g. its type, which find results do not include, with up to
+// latestVersionConcurrency requests at the same time. Credentials that are not
+// found or not readable, e.g. as they were deleted since they were found, are
+// left out and reported on stderr rather than failing the whole command.
+func getLatestMetadata(client *credhub.CredHub, names []string) (map[string]credentials.Metadata, error) {
+	found := make([]credentials.Metadata, len(names))
+	errs := make([]error, len(names))
+
+	forEachConcurrently(len(names), latestVersionConcurrency, func(i int) {
+		found[i], errs[i] = client.GetLatestMetadata(names[i])
+	})
 
-		printCredential(c.OutputJSON, output)
+	metadata := map[string]credentials.Metadata{}
+	for i, err := range errs {
+		var (
+			notFound  *credhub.NotFoundError
+			forbidden *credhub.ForbiddenError
+		)
+		switch {
+		case err == nil:
+			metadata[names[i]] = found[i]
+		case goerrors.As(err, &notFound), goerrors.As(err, &forbidden):
+			fmt.Fprintf(os.Stderr, "Skipping '%s': %s\n", names[i], err)
+		default:
+			return nil, err
+		}
 	}
 
-	return nil
+	return metadata, nil
+}
+
+// forEachConcurrently calls f with each index below n, with up to concurrency
+// calls at the same time, and returns when all calls have returned
+func forEachConcurrently(n, concurrency int, f func(i int)) {
+	var wg sync.WaitGroup
+	indexes := make(chan int)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 }
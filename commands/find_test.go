@@ -1,7 +1,9 @@
 package commands_test
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
 	"runtime"
 
@@ -136,6 +138,129 @@ var _ = Describe("Find", func() {
 				Eventually(session.Out).Should(Say(responseTable))
 				Eventually(session).Should(Exit(0))
 			})
+
+			It("can print the credential names with a template", func() {
+				responseJson := `{
+					"credentials": [
+							{
+								"name": "deploy123/dan.password",
+								"version_created_at": "2016-09-06T23:26:58Z"
+							},
+							{
+								"name": "deploy123/dan.key",
+								"version_created_at": "2016-09-06T23:26:58Z"
+							}
+					]
+				}`
+
+				server.RouteToHandler("GET", "/api/v1/data",
+					CombineHandlers(
+						VerifyRequest("GET", "/api/v1/data", "path=deploy123"),
+						RespondWith(http.StatusOK, responseJson),
+					),
+				)
+
+				session := runCommand("find", "-p", "deploy123", "--output", "template", "--template", "{{range .credentials}}{{.name}}\n{{end}}")
+
+				Eventually(session).Should(Exit(0))
+				Expect(string(session.Out.Contents())).To(Equal("deploy123/dan.password\ndeploy123/dan.key\n"))
+			})
+		})
+	})
+
+	Describe("filtering results", func() {
+		var types map[string]string
+
+		BeforeEach(func() {
+			types = map[string]string{
+				"/cf/prod/db_password": "password",
+				"/cf/prod/db_cert":     "certificate",
+				"/cf/dev/db_password":  "password",
+				"/cf/prod/nested/db_x": "value",
+			}
+
+			server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("path") == "/cf" {
+					w.Write([]byte(`{"credentials":[
+						{"name":"/cf/prod/db_password","version_created_at":"2017-03-01T00:00:00Z"},
+						{"name":"/cf/prod/db_cert","version_created_at":"2017-03-01T09:00:00+10:00"},
+						{"name":"/cf/dev/db_password","version_created_at":"2017-02-01T00:00:00Z"},
+						{"name":"/cf/prod/nested/db_x","version_created_at":"2016-01-01T00:00:00Z"}
+					]}`))
+					return
+				}
+
+				name := r.URL.Query().Get("name")
+				if types[name] == "" {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprint(w, `{"error":"The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`)
+					return
+				}
+				fmt.Fprintf(w, `{"data":[{"id":"some-id","name":"%s","type":"%s","value":"some-value","version_created_at":"2017-01-01T00:00:00Z"}]}`, name, types[name])
+			})
+		})
+
+		findNames := func(args ...string) []string {
+			session := runCommand(append([]string{"find", "-p", "/cf", "--output", "template", "--template", "{{range .credentials}}{{.name}}\n{{end}}"}, args...)...)
+			Eventually(session).Should(Exit(0))
+			return strings.Fields(string(session.Out.Contents()))
+		}
+
+		It("filters by glob, where '*' does not match '/'", func() {
+			Expect(findNames("--glob", "/cf/*/db_*")).To(Equal([]string{"/cf/prod/db_password", "/cf/prod/db_cert", "/cf/dev/db_password"}))
+		})
+
+		It("filters by regular expression", func() {
+			Expect(findNames("--regex", "password$")).To(Equal([]string{"/cf/prod/db_password", "/cf/dev/db_password"}))
+		})
+
+		It("filters by the time the credentials were last updated", func() {
+			Expect(findNames("--updated-after", "2017-01-15", "--updated-before", "2017-02-15T00:00:00Z")).To(Equal([]string{"/cf/dev/db_password"}))
+		})
+
+		It("filters by type", func() {
+			Expect(findNames("--type", "password", "--glob", "/cf/prod/*")).To(Equal([]string{"/cf/prod/db_password"}))
+		})
+
+		It("skips and reports credentials whose type cannot be read when filtering by type", func() {
+			delete(types, "/cf/prod/db_password")
+
+			session := runCommand("find", "-p", "/cf", "--type", "password", "--output", "template", "--template", "{{range .credentials}}{{.name}}\n{{end}}")
+
+			Eventually(session).Should(Exit(0))
+			Expect(strings.Fields(string(session.Out.Contents()))).To(Equal([]string{"/cf/dev/db_password"}))
+			Expect(session.Err).To(Say("Skipping '/cf/prod/db_password': The request could not be completed"))
+		})
+
+		It("sorts by name or by the most recently updated", func() {
+			Expect(findNames("--sort", "name")).To(Equal([]string{"/cf/dev/db_password", "/cf/prod/db_cert", "/cf/prod/db_password", "/cf/prod/nested/db_x"}))
+			Expect(findNames("--sort", "updated")).To(Equal([]string{"/cf/prod/db_password", "/cf/prod/db_cert", "/cf/dev/db_password", "/cf/prod/nested/db_x"}))
+		})
+
+		It("prints only the number of credentials found", func() {
+			session := runCommand("find", "-p", "/cf", "--regex", "db_password", "--count")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal("2\n"))
+
+			session = runCommand("find", "-p", "/cf", "--regex", "no-match", "--count", "-j")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out.Contents()).To(MatchJSON(`{"count":0}`))
+		})
+
+		It("rejects invalid filters", func() {
+			session := runCommand("find", "-p", "/cf", "--updated-after", "yesterday")
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The time 'yesterday' could not be parsed."))
+
+			session = runCommand("find", "-p", "/cf", "--regex", "(")
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The regular expression is not valid"))
+
+			session = runCommand("find", "-p", "/cf", "--sort", "size")
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The sort order is not valid."))
 		})
 	})
 
@@ -147,7 +272,7 @@ var _ = Describe("Find", func() {
 
 			session := runCommand("find")
 
-			Eventually(session).Should(Exit(1))
+			Eventually(session).Should(Exit(6))
 
 			Expect(session.Err).To(Say("test error: test description"))
 		})
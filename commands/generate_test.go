@@ -65,6 +65,15 @@ var _ = Describe("Generate", func() {
 			}`))
 		})
 
+		It("can print the generated password secret with a template", func() {
+			setupPasswordPostServer("my-password", "potatoes", generateDefaultTypeRequestJson("my-password", `{}`, credhub.Overwrite))
+
+			session := runCommand("generate", "-n", "my-password", "-t", "password", "--output", "template", "--template", "{{.id}}")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal(UUID))
+		})
+
 		It("allows the type to be any case", func() {
 			setupPasswordPostServer("my-password", "potatoes", generateDefaultTypeRequestJson("my-password", `{}`, credhub.Overwrite))
 
@@ -564,7 +573,7 @@ var _ = Describe("Generate", func() {
 
 			session := runCommand("generate", "-n", "my-value", "-t", "value")
 
-			Eventually(session).Should(Exit(1))
+			Eventually(session).Should(Exit(6))
 
 			Expect(session.Err).To(Say("test error"))
 		})
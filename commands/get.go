@@ -8,15 +8,19 @@ import (
 )
 
 type GetCommand struct {
-	Name             string `short:"n" long:"name" description:"Name of the credential to retrieve"`
-	ID               string `long:"id" description:"ID of the credential to retrieve"`
-	NumberOfVersions int    `long:"versions" description:"Number of versions of the credential to retrieve"`
-	OutputJSON       bool   `short:"j" long:"output-json" description:"Return response in JSON format"`
-	Key              string `short:"k" long:"key" description:"Return only the specified field of the requested credential"`
+	Name             CredentialName `short:"n" long:"name" description:"Name of the credential to retrieve"`
+	ID               string         `long:"id" description:"ID of the credential to retrieve"`
+	NumberOfVersions int            `long:"versions" description:"Number of versions of the credential to retrieve"`
+	Key              string         `short:"k" long:"key" description:"Return only the specified field of the requested credential. Nested fields can be selected with a path, e.g. '.db.primary.password' or '.servers[0].host'"`
+	OutputCommand
 	ClientCommand
 }
 
 func (c *GetCommand) Execute([]string) error {
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
 	var (
 		credential credentials.Credential
 		err        error
@@ -26,12 +30,9 @@ func (c *GetCommand) Execute([]string) error {
 
 	if c.Name != "" {
 		if c.NumberOfVersions != 0 {
-			if c.Key != "" {
-				return errors.NewGetVersionAndKeyError()
-			}
-			arrayOfCredentials, err = c.client.GetNVersions(c.Name, c.NumberOfVersions)
+			arrayOfCredentials, err = c.client.GetNVersions(string(c.Name), c.NumberOfVersions)
 		} else {
-			credential, err = c.client.GetLatestVersion(c.Name)
+			credential, err = c.client.GetLatestVersion(string(c.Name))
 		}
 	} else if c.ID != "" {
 		credential, err = c.client.GetById(c.ID)
@@ -44,31 +45,37 @@ func (c *GetCommand) Execute([]string) error {
 	}
 
 	if arrayOfCredentials != nil {
+		if c.Key != "" {
+			for i, version := range arrayOfCredentials {
+				field, _, err := selectField(version.Value, c.Key)
+				if err != nil {
+					return err
+				}
+				arrayOfCredentials[i].Value = field
+			}
+		}
+
 		output := map[string][]credentials.Credential{
 			"versions": arrayOfCredentials,
 		}
-		printCredential(c.OutputJSON, output)
+		return c.printOutput(output)
 	} else {
 		if c.Key != "" {
-			cred, ok := credential.Value.(map[string]interface{})
-			if !ok {
-				return nil
+			field, found, err := selectField(credential.Value, c.Key)
+			if err != nil {
+				return err
 			}
 
-			if cred[c.Key] == nil {
+			if !found {
 				return nil
 			}
-			switch cred[c.Key].(type) {
-			case string:
-				fmt.Println(cred[c.Key])
-
-			default:
-				printCredential(c.OutputJSON, cred[c.Key])
+			if value, ok := field.(string); ok && c.Output == "" {
+				fmt.Println(value)
+				return nil
 			}
+			return c.printOutput(field)
 		} else {
-			printCredential(c.OutputJSON, credential)
+			return c.printOutput(credential)
 		}
 	}
-
-	return nil
 }
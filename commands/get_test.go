@@ -80,6 +80,26 @@ var _ = Describe("Get", func() {
 		Eventually(session.Out).Should(Say("value: potatoes"))
 	})
 
+	It("writes redacted debug logs to stderr when CREDHUB_DEBUG is true", func() {
+		responseJson := fmt.Sprintf(STRING_CREDENTIAL_ARRAY_RESPONSE_JSON, "password", "my-password", "potatoes")
+
+		server.RouteToHandler("GET", "/api/v1/data",
+			CombineHandlers(
+				VerifyRequest("GET", "/api/v1/data", "current=true&name=my-password"),
+				RespondWith(http.StatusOK, responseJson),
+			),
+		)
+
+		session := runCommandWithEnv([]string{"CREDHUB_DEBUG=true"}, "get", "-n", "my-password")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("value: potatoes"))
+		Expect(session.Out.Contents()).NotTo(ContainSubstring("level=debug"))
+		Expect(session.Err).To(Say(`level=debug msg="response received" duration=\S+ method=GET path=/api/v1/data query="current=true&name=my-password" response_body=.*<redacted>.* status=200`))
+		Expect(session.Err.Contents()).NotTo(ContainSubstring("potatoes"))
+		Expect(session.Err.Contents()).NotTo(ContainSubstring("Bearer"))
+	})
+
 	It("gets a json secret", func() {
 		serverResponse := fmt.Sprintf(JSON_CREDENTIAL_ARRAY_RESPONSE_JSON, "json-secret", `{"foo":"bar","nested":{"a":1},"an":["array"]}`)
 
@@ -294,24 +314,160 @@ my-ca
 			})
 		})
 
-		Context("when there are a specified number of versions", func() {
-			It("returns an error", func() {
-				responseJson := `{"data":[{"type":"password","id":"` + UUID + `","name":"my-password","version_created_at":"` + TIMESTAMP + `","value":"old-password"},{"type":"password","id":"` + UUID + `","name":"my-password","version_created_at":"` + TIMESTAMP + `","value":"new-password"}]}`
+		Context("when the key is a path", func() {
+			BeforeEach(func() {
+				responseJson := fmt.Sprintf(JSON_CREDENTIAL_ARRAY_RESPONSE_JSON, "json-secret", `{"db":{"primary":{"password":"primary-password"},"replicas":[{"host":"replica-0"},{"host":"replica-1"}]},"key.with.dots":"dotted"}`)
 
 				server.RouteToHandler("GET", "/api/v1/data",
 					CombineHandlers(
-						VerifyRequest("GET", "/api/v1/data", "name=my-password&versions=2"),
+						VerifyRequest("GET", "/api/v1/data", "current=true&name=json-secret"),
 						RespondWith(http.StatusOK, responseJson),
 					),
 				)
+			})
+
+			It("returns the nested field", func() {
+				session := runCommand("get", "-n", "json-secret", "-k", ".db.primary.password")
+
+				Eventually(session).Should(Exit(0))
+				Expect(string(session.Out.Contents())).To(Equal("primary-password\n"))
+			})
+
+			It("supports array indexes", func() {
+				session := runCommand("get", "-n", "json-secret", "-k", "$.db.replicas[1].host")
+
+				Eventually(session).Should(Exit(0))
+				Expect(string(session.Out.Contents())).To(Equal("replica-1\n"))
+			})
+
+			It("prefers a top-level field whose name matches the key", func() {
+				session := runCommand("get", "-n", "json-secret", "-k", "key.with.dots")
+
+				Eventually(session).Should(Exit(0))
+				Expect(string(session.Out.Contents())).To(Equal("dotted\n"))
+			})
+
+			It("supports quoted keys", func() {
+				session := runCommand("get", "-n", "json-secret", "-k", "$['key.with.dots']")
+
+				Eventually(session).Should(Exit(0))
+				Expect(string(session.Out.Contents())).To(Equal("dotted\n"))
+			})
+
+			It("returns nothing when an index is out of range", func() {
+				session := runCommand("get", "-n", "json-secret", "-k", ".db.replicas[2].host")
+
+				Eventually(session).Should(Exit(0))
+				Expect(string(session.Out.Contents())).To(Equal(""))
+			})
+
+			It("returns an error when the path is malformed", func() {
+				session := runCommand("get", "-n", "json-secret", "-k", ".db[primary")
 
-				session := runCommand("get", "-n", "my-password", "--versions", "2", "-k", "someflag")
 				Eventually(session).Should(Exit(1))
-				Eventually(session.Err).Should(Say("The --version flag and --key flag are incompatible"))
+				Expect(session.Err).To(Say("The key '.db\\[primary' is not a valid path."))
+			})
+		})
+
+		Context("when there are a specified number of versions", func() {
+			It("returns the field of each version", func() {
+				responseJson := `{"data":[{"type":"json","id":"` + UUID + `","name":"my-json","version_created_at":"` + TIMESTAMP + `","value":{"db":{"password":"new-password"}}},{"type":"json","id":"` + UUID + `","name":"my-json","version_created_at":"` + TIMESTAMP + `","value":{"db":{"password":"old-password"}}}]}`
+
+				server.RouteToHandler("GET", "/api/v1/data",
+					CombineHandlers(
+						VerifyRequest("GET", "/api/v1/data", "name=my-json&versions=2"),
+						RespondWith(http.StatusOK, responseJson),
+					),
+				)
+
+				session := runCommand("get", "-n", "my-json", "--versions", "2", "-k", ".db.password", "-j")
+				Eventually(session).Should(Exit(0))
+				Expect(session.Out.Contents()).To(MatchJSON(`{"versions":[
+					{"type":"json","id":"` + UUID + `","name":"my-json","version_created_at":"` + TIMESTAMP + `","value":"new-password"},
+					{"type":"json","id":"` + UUID + `","name":"my-json","version_created_at":"` + TIMESTAMP + `","value":"old-password"}
+				]}`))
 			})
 		})
 	})
 
+	Describe("the --output flag", func() {
+		BeforeEach(func() {
+			responseJson := fmt.Sprintf(CERTIFICATE_CREDENTIAL_ARRAY_RESPONSE_JSON, "my-secret", "my-ca", `my\ncert`, "it's-my-priv")
+
+			server.RouteToHandler("GET", "/api/v1/data",
+				CombineHandlers(
+					VerifyRequest("GET", "/api/v1/data", "current=true&name=my-secret"),
+					RespondWith(http.StatusOK, responseJson),
+				),
+			)
+		})
+
+		It("prints shell variable exports with 'env'", func() {
+			session := runCommand("get", "-n", "my-secret", "--output", "env")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal(`export ID='` + UUID + `'
+export NAME='my-secret'
+export TYPE='certificate'
+export VALUE_CA='my-ca'
+export VALUE_CERTIFICATE='my
+cert'
+export VALUE_PRIVATE_KEY='it'"'"'s-my-priv'
+export VERSION_CREATED_AT='` + TIMESTAMP + `'
+`))
+		})
+
+		It("prints a .env file with 'dotenv'", func() {
+			session := runCommand("get", "-n", "my-secret", "--output", "dotenv")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say(`NAME="my-secret"\n`))
+			Expect(session.Out).To(Say(`VALUE_CERTIFICATE="my\\ncert"\n`))
+		})
+
+		It("prints only the value with 'raw'", func() {
+			session := runCommand("get", "-n", "my-secret", "-k", "certificate", "--output", "raw")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal("my\ncert\n"))
+		})
+
+		It("renders a Go template with 'template'", func() {
+			session := runCommand("get", "-n", "my-secret", "--output", "template", "--template", "{{.name}}: {{.value.ca}}")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal("my-secret: my-ca"))
+		})
+
+		It("requires a template for the 'template' output", func() {
+			session := runCommand("get", "-n", "my-secret", "--output", "template")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("A template must be provided with the --template flag when using the 'template' output format."))
+		})
+
+		It("returns an error when the template references a missing field", func() {
+			session := runCommand("get", "-n", "my-secret", "--output", "template", "--template", "{{.value.missing}}")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The provided template could not be rendered"))
+		})
+
+		It("rejects unknown output formats", func() {
+			session := runCommand("get", "-n", "my-secret", "--output", "xml")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The provided output format is not supported."))
+		})
+
+		It("may not be combined with --output-json", func() {
+			session := runCommand("get", "-n", "my-secret", "--output", "env", "-j")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The --output-json flag and --output flag are incompatible"))
+		})
+	})
+
 	It("does not use Printf on user-supplied data", func() {
 		responseJson := fmt.Sprintf(STRING_CREDENTIAL_ARRAY_RESPONSE_JSON, "password", "injected", "et''%/7(V&`|?m|Ckih$")
 
@@ -10,6 +10,8 @@ import (
 	"code.cloudfoundry.org/credhub-cli/config"
 	"code.cloudfoundry.org/credhub-cli/credhub"
 	"code.cloudfoundry.org/credhub-cli/credhub/auth"
+	"code.cloudfoundry.org/credhub-cli/credhub/auth/oidc"
+	"code.cloudfoundry.org/credhub-cli/credhub/auth/uaa"
 	"code.cloudfoundry.org/credhub-cli/errors"
 	"gopkg.in/yaml.v2"
 )
@@ -26,11 +28,8 @@ func initializeCredhubClient(cfg config.Config) (*credhub.CredHub, error) {
 		}
 	}
 
-	if clientCredentialsInEnvironment() {
-		credhubClient, err = newCredhubClient(&cfg, os.Getenv("CREDHUB_CLIENT"), os.Getenv("CREDHUB_SECRET"), true)
-	} else {
-		credhubClient, err = newCredhubClient(&cfg, config.AuthClient, config.AuthPassword, false)
-	}
+	clientId, clientSecret, usingClientCredentials := authClient(cfg)
+	credhubClient, err = newCredhubClient(&cfg, clientId, clientSecret, usingClientCredentials)
 
 	return credhubClient, err
 }
@@ -50,52 +49,110 @@ func printCredential(outputJson bool, v interface{}) {
 	}
 }
 
+// readConfigFromEnvironmentVariables fills the API URL and CA certificates of
+// cfg from the environment when they are not set, and the auth server URL from
+// the API when it is not set. The filled fields are saved in the config file,
+// which stays locked from reading the config until it is written.
 func readConfigFromEnvironmentVariables(cfg *config.Config) error {
-	if cfg.CaCerts == nil && os.Getenv("CREDHUB_CA_CERT") != "" {
-		caCerts, err := ReadOrGetCaCerts([]string{os.Getenv("CREDHUB_CA_CERT")})
-		if err != nil {
-			return err
+	if !needsConfigFromEnvironment(*cfg) {
+		return nil
+	}
+
+	return config.UpdateConfig(func(stored *config.Config) error {
+		if cfg.CaCerts == nil && os.Getenv("CREDHUB_CA_CERT") != "" {
+			caCerts, err := ReadOrGetCaCerts([]string{os.Getenv("CREDHUB_CA_CERT")})
+			if err != nil {
+				return err
+			}
+
+			cfg.CaCerts = caCerts
+			stored.CaCerts = caCerts
 		}
 
-		cfg.CaCerts = caCerts
-	}
+		if cfg.ApiURL == "" && os.Getenv("CREDHUB_SERVER") != "" {
+			cfg.ApiURL = os.Getenv("CREDHUB_SERVER")
+			stored.ApiURL = cfg.ApiURL
+		}
 
-	if cfg.ApiURL == "" && os.Getenv("CREDHUB_SERVER") != "" {
-		cfg.ApiURL = os.Getenv("CREDHUB_SERVER")
-	}
+		if cfg.AuthURL == "" && cfg.ApiURL != "" {
+			credhubInfo, err := GetApiInfo(*cfg)
+			if err != nil {
+				return errors.NewNetworkError(err)
+			}
 
-	if cfg.AuthURL == "" && cfg.ApiURL != "" {
-		credhubInfo, err := GetApiInfo(cfg.ApiURL, cfg.CaCerts, cfg.InsecureSkipVerify)
-		if err != nil {
-			return errors.NewNetworkError(err)
+			cfg.AuthURL = credhubInfo.AuthServer.URL
+			if stored.ApiURL == cfg.ApiURL {
+				stored.AuthURL = cfg.AuthURL
+			}
 		}
 
-		cfg.AuthURL = credhubInfo.AuthServer.URL
-	}
+		return nil
+	})
+}
 
-	return config.WriteConfig(*cfg)
+func needsConfigFromEnvironment(cfg config.Config) bool {
+	return (cfg.CaCerts == nil && os.Getenv("CREDHUB_CA_CERT") != "") ||
+		(cfg.ApiURL == "" && os.Getenv("CREDHUB_SERVER") != "") ||
+		(cfg.AuthURL == "" && cfg.ApiURL != "")
 }
 
 func newCredhubClient(cfg *config.Config, clientId string, clientSecret string, usingClientCredentials bool) (*credhub.CredHub, error) {
-	credhubClient, err := credhub.New(cfg.ApiURL, credhub.CaCerts(cfg.CaCerts...), credhub.SkipTLSValidation(cfg.InsecureSkipVerify), credhub.Auth(auth.Uaa(
+	credhubClient, err := credhub.New(cfg.ApiURL, credhub.CaCerts(cfg.CaCerts...), credhub.SkipTLSValidation(cfg.InsecureSkipVerify), credhub.Proxy(cfg.Proxy), credhub.Auth(AuthBuilder(
+		*cfg,
 		clientId,
 		clientSecret,
-		"",
-		"",
-		cfg.AccessToken,
-		cfg.RefreshToken,
 		usingClientCredentials,
 	)),
 		credhub.AuthURL(cfg.AuthURL))
 	return credhubClient, err
 }
 
+// AuthBuilder builds the auth strategy for the auth server type of cfg using
+// the tokens of cfg
+func AuthBuilder(cfg config.Config, clientId, clientSecret string, usingClientCredentials bool) auth.Builder {
+	if cfg.AuthType == config.AuthTypeOidc {
+		return auth.Oidc(clientId, clientSecret, "", "", cfg.AccessToken, cfg.RefreshToken, usingClientCredentials)
+	}
+	return auth.Uaa(clientId, clientSecret, "", "", cfg.AccessToken, cfg.RefreshToken, usingClientCredentials)
+}
+
+// newOAuthClient returns a client of the auth server of cfg for the auth
+// server type of cfg. Revocation requests to an OpenID Connect provider are
+// authenticated with clientId and clientSecret.
+func newOAuthClient(cfg config.Config, httpClient *http.Client, clientId, clientSecret string) auth.OAuthClient {
+	if cfg.AuthType == config.AuthTypeOidc {
+		return &oidc.Client{
+			Issuer:       cfg.AuthURL,
+			ClientId:     clientId,
+			ClientSecret: clientSecret,
+			Client:       httpClient,
+		}
+	}
+	return &uaa.Client{
+		AuthURL: cfg.AuthURL,
+		Client:  httpClient,
+	}
+}
+
+// authClient returns the client to authenticate with, which is the client of
+// CREDHUB_CLIENT and CREDHUB_SECRET when they are set, then the client of cfg,
+// and then the client of the CLI, whose tokens are obtained with a user login
+func authClient(cfg config.Config) (clientId, clientSecret string, usingClientCredentials bool) {
+	if clientCredentialsInEnvironment() {
+		return os.Getenv("CREDHUB_CLIENT"), os.Getenv("CREDHUB_SECRET"), true
+	}
+	if cfg.ClientID != "" {
+		return cfg.ClientID, cfg.ClientSecret, true
+	}
+	return config.AuthClient, config.AuthPassword, false
+}
+
 func clientCredentialsInEnvironment() bool {
 	return os.Getenv("CREDHUB_CLIENT") != "" || os.Getenv("CREDHUB_SECRET") != ""
 }
 
 func verifyAuthServerConnection(cfg config.Config, skipTlsValidation bool) error {
-	credhubClient, err := credhub.New(cfg.ApiURL, credhub.CaCerts(cfg.CaCerts...), credhub.SkipTLSValidation(skipTlsValidation))
+	credhubClient, err := credhub.New(cfg.ApiURL, credhub.CaCerts(cfg.CaCerts...), credhub.SkipTLSValidation(skipTlsValidation), credhub.Proxy(cfg.Proxy))
 	if err != nil {
 		return err
 	}
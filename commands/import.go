@@ -5,18 +5,29 @@ import (
 
 	"os"
 
-	"reflect"
-
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
 	"code.cloudfoundry.org/credhub-cli/errors"
 	"code.cloudfoundry.org/credhub-cli/models"
 )
 
 type ImportCommand struct {
 	File string `short:"f" long:"file" description:"File containing credentials to import" required:"true"`
+	OutputCommand
 	ClientCommand
 }
 
+type importSummary struct {
+	Credentials []credentials.Credential `json:"credentials" yaml:"credentials"`
+	Successful  int                      `json:"successful" yaml:"successful"`
+	Failed      int                      `json:"failed" yaml:"failed"`
+	Errors      []string                 `json:"errors" yaml:"errors"`
+}
+
 func (c *ImportCommand) Execute([]string) error {
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
 	var bulkImport models.CredentialBulkImport
 	err := bulkImport.ReadFile(c.File)
 
@@ -36,6 +47,7 @@ func (c *ImportCommand) setCredentials(bulkImport models.CredentialBulkImport) e
 		failed     int
 	)
 	errors := make([]string, 0)
+	results := make([]credentials.Credential, 0)
 
 	for i, credential := range bulkImport.Credentials {
 		switch credentialName := credential["name"].(type) {
@@ -52,14 +64,32 @@ func (c *ImportCommand) setCredentials(bulkImport models.CredentialBulkImport) e
 				return err
 			}
 			failure := fmt.Sprintf("Credential '%s' at index %d could not be set: %v", name, i, err)
-			fmt.Println(failure + "\n")
-			errors = append(errors, " - "+failure)
+			if c.isDefaultOutput() {
+				fmt.Println(failure + "\n")
+				errors = append(errors, " - "+failure)
+			} else {
+				errors = append(errors, failure)
+			}
 			failed++
 			continue
 		} else {
 			successful++
 		}
-		printCredential(false, result)
+
+		if c.isDefaultOutput() {
+			printCredential(false, result)
+		} else {
+			results = append(results, result)
+		}
+	}
+
+	if !c.isDefaultOutput() {
+		return c.printOutput(importSummary{
+			Credentials: results,
+			Successful:  successful,
+			Failed:      failed,
+			Errors:      errors,
+		})
 	}
 
 	fmt.Println("Import complete.")
@@ -73,7 +103,6 @@ func (c *ImportCommand) setCredentials(bulkImport models.CredentialBulkImport) e
 }
 
 func isAuthenticationError(err error) bool {
-	return reflect.DeepEqual(err, errors.NewNoApiUrlSetError()) ||
-		reflect.DeepEqual(err, errors.NewRevokedTokenError()) ||
-		reflect.DeepEqual(err, errors.NewRefreshError())
+	return ExitCode(err) == ExitCodeAuth ||
+		err.Error() == errors.NewNoApiUrlSetError().Error()
 }
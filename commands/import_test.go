@@ -237,6 +237,41 @@ Failed to set: 2
 		})
 	})
 
+	Describe("when a summary is requested with --output", func() {
+		It("prints the results and the failures as a single document", func() {
+			error := "The request does not include a valid type. Valid values include 'value', 'json', 'password', 'user', 'certificate', 'ssh' and 'rsa'."
+
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest("PUT", "/api/v1/data"),
+					RespondWith(http.StatusBadRequest, `{"error": "`+error+`"}`)),
+				CombineHandlers(
+					VerifyRequest("PUT", "/api/v1/data"),
+					RespondWith(http.StatusBadRequest, `{"error": "`+error+`"}`)),
+			)
+			SetupPutUserServer("/test/user", `{"username": "covfefe", "password": "test-user-password"}`, "covfefe", "test-user-password", "P455W0rd-H45H")
+
+			session := runCommand("import", "-f", "../test/test_import_partial_fail_set.yml", "--output", "json")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out.Contents()).To(MatchJSON(`{
+				"credentials": [{
+					"id": "` + UUID + `",
+					"name": "/test/user",
+					"type": "user",
+					"version_created_at": "` + TIMESTAMP + `",
+					"value": {"username": "covfefe", "password": "test-user-password", "password_hash": "P455W0rd-H45H"}
+				}],
+				"successful": 1,
+				"failed": 2,
+				"errors": [
+					"Credential '/test/invalid_type' at index 0 could not be set: ` + error + `",
+					"Credential '/test/invalid_type1' at index 1 could not be set: ` + error + `"
+				]
+			}`))
+		})
+	})
+
 	Describe("when no credential tag present in import file", func() {
 		It("prints correct error message", func() {
 			session := runCommand("import", "-f", "../test/test_import_incorrect_format.yml")
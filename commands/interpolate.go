@@ -1,17 +1,28 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path"
+	"sort"
+	"strings"
 
+	"code.cloudfoundry.org/credhub-cli/credhub"
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
 	"code.cloudfoundry.org/credhub-cli/errors"
 	"github.com/cloudfoundry/bosh-cli/director/template"
 )
 
 type InterpolateCommand struct {
-	File   string `short:"f" long:"file"   description:"Path to the file to interpolate"`
-	Prefix string `short:"p" long:"prefix" description:"Prefix to be applied to credential paths. Will not be applied to paths that start with '/'"`
+	File    string   `short:"f" long:"file"   description:"Path to the file to interpolate"`
+	Prefix  []string `short:"p" long:"prefix" description:"Prefix to be applied to credential paths. Will not be applied to paths that start with '/'. When specified multiple times, prefixes are tried in order until the credential is found"`
+	Explain bool     `long:"explain" description:"Print the path each credential was resolved from to stderr"`
+	Format  string   `long:"format" description:"Format of the file to interpolate. Valid formats include 'yaml', 'json' and 'text' (Default: yaml)"`
+	Escape  string   `long:"escape" description:"[Text] Escaping applied to substituted values. Valid escapings include 'none', 'json' and 'shell' (Default: none)"`
 	ClientCommand
 }
 
@@ -20,6 +31,17 @@ func (c *InterpolateCommand) Execute([]string) error {
 		return errors.NewMissingInterpolateParametersError()
 	}
 
+	format := strings.ToLower(c.Format)
+	if format == "" {
+		format = "yaml"
+	}
+	if format != "yaml" && format != "json" && format != "text" {
+		return errors.NewInvalidInterpolateFormatError()
+	}
+	if c.Escape != "" && format != "text" {
+		return errors.NewEscapeOnlyValidForTextFormatError()
+	}
+
 	fileContents, err := ioutil.ReadFile(c.File)
 	if err != nil {
 		return err
@@ -29,36 +51,114 @@ func (c *InterpolateCommand) Execute([]string) error {
 		return errors.NewEmptyTemplateError(c.File)
 	}
 
-	initialTemplate := template.NewTemplate(fileContents)
-
 	credGetter := credentialGetter{
 		clientCommand: c.ClientCommand,
-		prefix:        c.Prefix,
+		prefixes:      c.Prefix,
+		resolved:      map[string]string{},
+	}
+
+	if format == "text" {
+		escaper, err := escaperFor(c.Escape)
+		if err != nil {
+			return err
+		}
+
+		rendered, err := credhub.ExpandText(string(fileContents), credGetter.lookup, escaper)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(rendered)
+		c.explain(credGetter)
+		return nil
 	}
 
+	if format == "json" {
+		rendered, err := credhub.ExpandJSON(fileContents, credGetter.lookup)
+		if err != nil {
+			return err
+		}
+
+		indented := &bytes.Buffer{}
+		if err := json.Indent(indented, rendered, "", "\t"); err != nil {
+			return err
+		}
+		fmt.Println(indented.String())
+		c.explain(credGetter)
+		return nil
+	}
+
+	initialTemplate := template.NewTemplate(fileContents)
+
 	renderedTemplate, err := initialTemplate.Evaluate(credGetter, nil, template.EvaluateOpts{ExpectAllKeys: true})
 	if err != nil {
 		return err
 	}
 
 	fmt.Println(string(renderedTemplate))
+	c.explain(credGetter)
 	return nil
 }
 
+func (c *InterpolateCommand) explain(credGetter credentialGetter) {
+	if !c.Explain {
+		return
+	}
+
+	names := make([]string, 0, len(credGetter.resolved))
+	for name := range credGetter.resolved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "((%s)) resolved from %s\n", name, credGetter.resolved[name])
+	}
+}
+
+func escaperFor(escape string) (credhub.Escaper, error) {
+	switch strings.ToLower(escape) {
+	case "", "none":
+		return credhub.RawEscaper, nil
+	case "json":
+		return credhub.JSONEscaper, nil
+	case "shell":
+		return credhub.ShellEscaper, nil
+	default:
+		return nil, errors.NewInvalidInterpolateEscapeError()
+	}
+}
+
+// toJSONCompatible converts the map[interface{}]interface{} values produced by
+// the YAML decoder into map[string]interface{} so they can be encoded as JSON
+func toJSONCompatible(v interface{}) interface{} {
+	switch typed := v.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, val := range typed {
+			m[fmt.Sprintf("%v", k)] = toJSONCompatible(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range typed {
+			typed[i] = toJSONCompatible(val)
+		}
+		return typed
+	}
+	return v
+}
+
 type credentialGetter struct {
 	clientCommand ClientCommand
-	prefix        string
+	prefixes      []string
+	resolved      map[string]string
 }
 
 func (v credentialGetter) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
-	credName := varDef.Name
-	if !path.IsAbs(varDef.Name) {
-		credName = path.Join(v.prefix, credName)
-	}
+	value, err := v.lookup(varDef.Name)
 
-	credential, err := v.clientCommand.client.GetLatestVersion(credName)
-	var result = credential.Value
-	if mapString, ok := credential.Value.(map[string]interface{}); ok {
+	var result = value
+	if mapString, ok := value.(map[string]interface{}); ok {
 		mapInterface := map[interface{}]interface{}{}
 		for k, v := range mapString {
 			mapInterface[k] = v
@@ -68,6 +168,36 @@ func (v credentialGetter) Get(varDef template.VariableDefinition) (interface{},
 	return result, true, err
 }
 
+func (v credentialGetter) lookup(name string) (interface{}, error) {
+	candidates := []string{name}
+	if !path.IsAbs(name) && len(v.prefixes) > 0 {
+		candidates = make([]string, len(v.prefixes))
+		for i, prefix := range v.prefixes {
+			candidates[i] = path.Join(prefix, name)
+		}
+	}
+
+	var err error
+	for _, credName := range candidates {
+		var credential credentials.Credential
+		credential, err = v.clientCommand.client.GetLatestVersion(credName)
+		if err == nil {
+			v.resolved[name] = credName
+			return credential.Value, nil
+		}
+
+		var notFound *credhub.NotFoundError
+		if !goerrors.As(err, &notFound) {
+			return nil, err
+		}
+	}
+
+	if len(candidates) > 1 {
+		return nil, errors.NewNotFoundInPrefixesError(candidates, err)
+	}
+	return nil, err
+}
+
 func (v credentialGetter) List() ([]template.VariableDefinition, error) {
 	// not implemented
 	return []template.VariableDefinition{}, nil
@@ -157,6 +157,131 @@ static-value: a normal string
 		})
 	})
 
+	Describe("multiple --prefix flags", func() {
+		BeforeEach(func() {
+			templateText = `---
+pipeline-specific: ((foo))
+team-wide: ((bar))`
+			templateFile.WriteString(templateText)
+
+			server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Query().Get("name") {
+				case "/concourse/team/pipeline/foo":
+					fmt.Fprintf(w, STRING_CREDENTIAL_ARRAY_RESPONSE_JSON, "value", "/concourse/team/pipeline/foo", "pipeline-foo")
+				case "/concourse/team/bar":
+					fmt.Fprintf(w, STRING_CREDENTIAL_ARRAY_RESPONSE_JSON, "value", "/concourse/team/bar", "team-bar")
+				case "/forbidden/foo", "/forbidden/bar":
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprint(w, `{"error":"The request could not be completed because you do not have sufficient authorization."}`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"error":"The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`)
+				}
+			})
+		})
+
+		It("tries the prefixes in order", func() {
+			session = runCommand("interpolate", "-f", templateFile.Name(), "-p", "/concourse/team/pipeline", "-p", "/concourse/team")
+			Eventually(session).Should(gexec.Exit(0))
+			Expect(string(session.Out.Contents())).To(MatchYAML(`
+pipeline-specific: pipeline-foo
+team-wide: team-bar
+`))
+		})
+
+		It("reports where each credential was resolved from with --explain", func() {
+			session = runCommand("interpolate", "-f", templateFile.Name(), "-p", "/concourse/team/pipeline", "-p", "/concourse/team", "--explain")
+			Eventually(session).Should(gexec.Exit(0))
+			Expect(session.Err).To(Say(`\(\(bar\)\) resolved from /concourse/team/bar`))
+			Expect(session.Err).To(Say(`\(\(foo\)\) resolved from /concourse/team/pipeline/foo`))
+		})
+
+		It("prints the paths that were tried when none of the prefixes match", func() {
+			session = runCommand("interpolate", "-f", templateFile.Name(), "-p", "/other/pipeline", "-p", "/other")
+			Eventually(session).Should(gexec.Exit(1))
+			Expect(session.Err).To(Say("None of the paths /other/pipeline/"))
+		})
+
+		It("does not try the next prefix when a lookup fails for any reason other than not found", func() {
+			session = runCommand("interpolate", "-f", templateFile.Name(), "-p", "/forbidden", "-p", "/concourse/team")
+			Eventually(session).Should(gexec.Exit(1))
+			Expect(session.Err).To(Say("you do not have sufficient authorization"))
+			Expect(session.Err).NotTo(Say("None of the paths"))
+		})
+	})
+
+	Describe("the optional --format flag", func() {
+		BeforeEach(func() {
+			responseValueJson := fmt.Sprintf(STRING_CREDENTIAL_ARRAY_RESPONSE_JSON, "password", "/db/password", `it's \"quoted\"`)
+
+			server.RouteToHandler("GET", "/api/v1/data",
+				CombineHandlers(
+					VerifyRequest("GET", "/api/v1/data", "current=true&name=/db/password"),
+					RespondWith(http.StatusOK, responseValueJson),
+				),
+			)
+		})
+
+		Context("when the format is text", func() {
+			BeforeEach(func() {
+				templateText = "# database settings\nDB_PASSWORD=((/db/password))\n"
+				templateFile.WriteString(templateText)
+			})
+
+			It("substitutes values as raw strings", func() {
+				session = runCommand("interpolate", "-f", templateFile.Name(), "--format", "text")
+				Eventually(session).Should(gexec.Exit(0))
+				Expect(string(session.Out.Contents())).To(Equal("# database settings\nDB_PASSWORD=it's \"quoted\"\n"))
+			})
+
+			It("quotes values as shell words with --escape shell", func() {
+				session = runCommand("interpolate", "-f", templateFile.Name(), "--format", "text", "--escape", "shell")
+				Eventually(session).Should(gexec.Exit(0))
+				Expect(string(session.Out.Contents())).To(Equal("# database settings\nDB_PASSWORD='it'\"'\"'s \"quoted\"'\n"))
+			})
+
+			It("escapes values as JSON string content with --escape json", func() {
+				session = runCommand("interpolate", "-f", templateFile.Name(), "--format", "text", "--escape", "json")
+				Eventually(session).Should(gexec.Exit(0))
+				Expect(string(session.Out.Contents())).To(Equal("# database settings\nDB_PASSWORD=it's \\\"quoted\\\"\n"))
+			})
+		})
+
+		Context("when the format is json", func() {
+			It("prints the interpolated document as JSON", func() {
+				templateText = `{"db": {"password": "((/db/password))", "port": 5432}}`
+				templateFile.WriteString(templateText)
+
+				session = runCommand("interpolate", "-f", templateFile.Name(), "--format", "json")
+				Eventually(session).Should(gexec.Exit(0))
+				Expect(string(session.Out.Contents())).To(MatchJSON(`{"db": {"password": "it's \"quoted\"", "port": 5432}}`))
+			})
+
+			It("keeps the order of the keys and the numbers of the document", func() {
+				templateText = `{"zone": "a", "db": {"password": "((/db/password))", "max_connections": 1000000}}`
+				templateFile.WriteString(templateText)
+
+				session = runCommand("interpolate", "-f", templateFile.Name(), "--format", "json")
+				Eventually(session).Should(gexec.Exit(0))
+				Expect(string(session.Out.Contents())).To(Equal("{\n\t\"zone\": \"a\",\n\t\"db\": {\n\t\t\"password\": \"it's \\\"quoted\\\"\",\n\t\t\"max_connections\": 1000000\n\t}\n}\n"))
+			})
+		})
+
+		It("rejects unknown formats", func() {
+			templateFile.WriteString("---")
+			session = runCommand("interpolate", "-f", templateFile.Name(), "--format", "toml")
+			Eventually(session).Should(gexec.Exit(1))
+			Expect(session.Err).To(Say("The provided format is not supported. Valid formats include 'yaml', 'json' and 'text'."))
+		})
+
+		It("rejects --escape without the text format", func() {
+			templateFile.WriteString("---")
+			session = runCommand("interpolate", "-f", templateFile.Name(), "--escape", "shell")
+			Eventually(session).Should(gexec.Exit(1))
+			Expect(session.Err).To(Say("The --escape flag may only be used with the 'text' format."))
+		})
+	})
+
 	Describe("Errors", func() {
 		Context("when no template file is provided", func() {
 			BeforeEach(func() {
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/credhub-cli/errors"
+)
+
+// selectField returns the field of a credential value selected by key.
+//
+// The key is either the name of a top-level field, or a path expression such as
+// '.db.primary.password', 'servers[0].host' or "$['key.with.dots']". The second
+// return value is false when the value does not contain the selected field.
+func selectField(value interface{}, key string) (interface{}, bool, error) {
+	if m, ok := value.(map[string]interface{}); ok {
+		if field, ok := m[key]; ok {
+			return field, field != nil, nil
+		}
+	}
+
+	tokens, err := parseKeyPath(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, token := range tokens {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			field, ok := typed[token]
+			if !ok {
+				return nil, false, nil
+			}
+			value = field
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(typed) {
+				return nil, false, nil
+			}
+			value = typed[index]
+		default:
+			return nil, false, nil
+		}
+	}
+
+	return value, value != nil, nil
+}
+
+// parseKeyPath splits a path expression into map keys and array indexes
+func parseKeyPath(key string) ([]string, error) {
+	path := strings.TrimPrefix(key, "$")
+	var tokens []string
+
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			if path == "" || path[0] == '.' {
+				return nil, errors.NewInvalidKeyPathError(key)
+			}
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, errors.NewInvalidKeyPathError(key)
+			}
+			token := path[1:end]
+			if len(token) >= 2 && (token[0] == '\'' || token[0] == '"') && token[len(token)-1] == token[0] {
+				token = token[1 : len(token)-1]
+			} else if _, err := strconv.Atoi(token); err != nil {
+				return nil, errors.NewInvalidKeyPathError(key)
+			}
+			tokens = append(tokens, token)
+			path = path[end+1:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			tokens = append(tokens, path[:end])
+			path = path[end:]
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.NewInvalidKeyPathError(key)
+	}
+
+	return tokens, nil
+}
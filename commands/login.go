@@ -1,13 +1,13 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 
 	"os"
 
 	"code.cloudfoundry.org/credhub-cli/config"
 	"code.cloudfoundry.org/credhub-cli/credhub"
-	"code.cloudfoundry.org/credhub-cli/credhub/auth"
 	"code.cloudfoundry.org/credhub-cli/credhub/auth/uaa"
 	"code.cloudfoundry.org/credhub-cli/errors"
 	"code.cloudfoundry.org/credhub-cli/util"
@@ -24,6 +24,7 @@ type LoginCommand struct {
 	SkipTlsValidation bool     `long:"skip-tls-validation" description:"Skip certificate validation of the API endpoint. Not recommended!"`
 	SSO               bool     `long:"sso" description:"Prompt for a one-time passcode to login"`
 	SSOPasscode       string   `long:"sso-passcode" description:"One-time passcode"`
+	Device            bool     `long:"device" description:"Login by entering a code on another device, e.g. for sessions without a browser"`
 	ConfigCommand
 }
 
@@ -42,6 +43,10 @@ func (c *LoginCommand) Execute([]string) error {
 		c.config.InsecureSkipVerify = c.SkipTlsValidation
 
 		serverUrl := util.AddDefaultSchemeIfNecessary(c.ServerUrl)
+		if serverUrl != c.config.ApiURL {
+			c.config.Proxy = ""
+			c.config.AuthType = ""
+		}
 		c.config.ApiURL = serverUrl
 
 		err := c.config.UpdateTrustedCAs(c.CaCerts)
@@ -49,7 +54,7 @@ func (c *LoginCommand) Execute([]string) error {
 			return err
 		}
 
-		credhubInfo, err := GetApiInfo(serverUrl, c.config.CaCerts, c.SkipTlsValidation)
+		credhubInfo, err := GetApiInfo(c.config)
 		if err != nil {
 			return errors.NewNetworkError(err)
 		}
@@ -66,7 +71,14 @@ func (c *LoginCommand) Execute([]string) error {
 	if err != nil {
 		return err
 	}
-	credhubClient, err := credhub.New(c.config.ApiURL, credhub.CaCerts(c.config.CaCerts...), credhub.SkipTLSValidation(c.config.InsecureSkipVerify))
+
+	if c.config.AuthType == config.AuthTypeOidc {
+		if option := uaaLoginOption(c); option != "" {
+			return errors.NewUnsupportedWithOidcError(option)
+		}
+	}
+
+	credhubClient, err := credhub.New(c.config.ApiURL, credhub.CaCerts(c.config.CaCerts...), credhub.SkipTLSValidation(c.config.InsecureSkipVerify), credhub.Proxy(c.config.Proxy))
 	if err != nil {
 		return err
 	}
@@ -75,16 +87,19 @@ func (c *LoginCommand) Execute([]string) error {
 		AuthURL: c.config.AuthURL,
 		Client:  credhubClient.Client(),
 	}
+	oauthClient := newOAuthClient(c.config, credhubClient.Client(), config.AuthClient, config.AuthPassword)
 
 	if c.ClientName != "" || c.ClientSecret != "" {
-		accessToken, err = uaaClient.ClientCredentialGrant(c.ClientName, c.ClientSecret)
+		accessToken, err = oauthClient.ClientCredentialGrant(c.ClientName, c.ClientSecret)
+	} else if c.Device {
+		accessToken, refreshToken, err = deviceLogin(&uaaClient)
 	} else {
 		err = promptForMissingCredentials(c, &uaaClient)
 		if err == nil {
 			if c.SSOPasscode != "" {
 				accessToken, refreshToken, err = uaaClient.PasscodeGrant(config.AuthClient, config.AuthPassword, c.SSOPasscode)
 			} else {
-				accessToken, refreshToken, err = uaaClient.PasswordGrant(config.AuthClient, config.AuthPassword, c.Username, c.Password)
+				accessToken, refreshToken, err = oauthClient.PasswordGrant(config.AuthClient, config.AuthPassword, c.Username, c.Password)
 			}
 		}
 	}
@@ -92,7 +107,7 @@ func (c *LoginCommand) Execute([]string) error {
 	if err != nil {
 		RevokeTokenIfNecessary(c.config)
 		MarkTokensAsRevokedInConfig(&c.config)
-		config.WriteConfig(c.config)
+		c.saveConfig()
 		return errors.NewUAAError(err)
 	}
 
@@ -107,8 +122,9 @@ func (c *LoginCommand) Execute([]string) error {
 	credhubClient, err = credhub.New(c.config.ApiURL,
 		credhub.CaCerts(c.config.CaCerts...),
 		credhub.SkipTLSValidation(c.config.InsecureSkipVerify),
+		credhub.Proxy(c.config.Proxy),
 		credhub.AuthURL(c.config.AuthURL),
-		credhub.Auth(auth.Uaa(c.ClientName, c.ClientSecret, "", "", c.config.AccessToken, "", true)),
+		credhub.Auth(AuthBuilder(config.Config{AuthType: c.config.AuthType, AccessToken: c.config.AccessToken}, c.ClientName, c.ClientSecret, true)),
 	)
 
 	if err != nil {
@@ -122,7 +138,7 @@ func (c *LoginCommand) Execute([]string) error {
 
 	c.config.ServerVersion = version.String()
 
-	if err := config.WriteConfig(c.config); err != nil {
+	if err := c.saveConfig(); err != nil {
 		return err
 	}
 
@@ -141,7 +157,7 @@ func validateParameters(cmd *LoginCommand) error {
 	// Intent is client credentials
 	case cmd.ClientName != "" || cmd.ClientSecret != "":
 		// Make sure nothing else is specified
-		if cmd.Username != "" || cmd.Password != "" || cmd.SSO || cmd.SSOPasscode != "" {
+		if cmd.Username != "" || cmd.Password != "" || cmd.SSO || cmd.SSOPasscode != "" || cmd.Device {
 			return errors.NewMixedAuthorizationParametersError()
 		}
 
@@ -155,7 +171,7 @@ func validateParameters(cmd *LoginCommand) error {
 		// Intent is SSO passcode
 	case cmd.SSOPasscode != "":
 		// Make sure nothing else is specified
-		if cmd.ClientName != "" || cmd.ClientSecret != "" || cmd.Username != "" || cmd.Password != "" || cmd.SSO {
+		if cmd.ClientName != "" || cmd.ClientSecret != "" || cmd.Username != "" || cmd.Password != "" || cmd.SSO || cmd.Device {
 			return errors.NewMixedAuthorizationParametersError()
 		}
 
@@ -164,7 +180,16 @@ func validateParameters(cmd *LoginCommand) error {
 		// Intent is to be prompted for token
 	case cmd.SSO:
 		// Make sure nothing else is specified
-		if cmd.ClientName != "" || cmd.ClientSecret != "" || cmd.Username != "" || cmd.Password != "" || cmd.SSOPasscode != "" {
+		if cmd.ClientName != "" || cmd.ClientSecret != "" || cmd.Username != "" || cmd.Password != "" || cmd.SSOPasscode != "" || cmd.Device {
+			return errors.NewMixedAuthorizationParametersError()
+		}
+
+		return nil
+
+		// Intent is device authorization
+	case cmd.Device:
+		// Make sure nothing else is specified
+		if cmd.Username != "" || cmd.Password != "" {
 			return errors.NewMixedAuthorizationParametersError()
 		}
 
@@ -186,6 +211,37 @@ func validateParameters(cmd *LoginCommand) error {
 	}
 }
 
+// uaaLoginOption returns the provided login option that is only supported by
+// UAA, if any
+func uaaLoginOption(cmd *LoginCommand) string {
+	switch {
+	case cmd.SSO:
+		return "--sso"
+	case cmd.SSOPasscode != "":
+		return "--sso-passcode"
+	case cmd.Device:
+		return "--device"
+	}
+	return ""
+}
+
+// deviceLogin shows the user where to approve the login and waits until it
+// is approved, see RFC 8628
+func deviceLogin(uaaClient *uaa.Client) (string, string, error) {
+	authorization, err := uaaClient.DeviceAuthorizationRequest(config.AuthClient, config.AuthPassword)
+	if err != nil {
+		return "", "", err
+	}
+
+	fmt.Printf("To log in, visit %s and enter the code: %s\n", authorization.VerificationURI, authorization.UserCode)
+	if authorization.VerificationURIComplete != "" {
+		fmt.Printf("Or visit %s\n", authorization.VerificationURIComplete)
+	}
+	fmt.Println("Waiting for the login to be approved...")
+
+	return uaaClient.DeviceCodeGrant(context.Background(), config.AuthClient, config.AuthPassword, authorization)
+}
+
 func promptForMissingCredentials(cmd *LoginCommand, uaa *uaa.Client) error {
 	if cmd.SSO || cmd.SSOPasscode != "" {
 		if cmd.SSOPasscode == "" {
@@ -213,3 +269,24 @@ func promptForMissingCredentials(cmd *LoginCommand, uaa *uaa.Client) error {
 	}
 	return nil
 }
+
+// saveConfig saves the tokens and server version of the login, and the target
+// when one was given, in the config file. The other fields of the config file
+// are kept as they are when it is saved, as other commands may have changed
+// them since the login started.
+func (c *LoginCommand) saveConfig() error {
+	return config.UpdateConfig(func(stored *config.Config) error {
+		if c.ServerUrl != "" {
+			stored.ApiURL = c.config.ApiURL
+			stored.AuthURL = c.config.AuthURL
+			stored.AuthType = c.config.AuthType
+			stored.CaCerts = c.config.CaCerts
+			stored.InsecureSkipVerify = c.config.InsecureSkipVerify
+			stored.Proxy = c.config.Proxy
+		}
+		stored.AccessToken = c.config.AccessToken
+		stored.RefreshToken = c.config.RefreshToken
+		stored.ServerVersion = c.config.ServerVersion
+		return nil
+	})
+}
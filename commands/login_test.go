@@ -335,6 +335,79 @@ var _ = Describe("Login", func() {
 		})
 	})
 
+	Describe("device flow", func() {
+		var tokenRequests int
+
+		BeforeEach(func() {
+			tokenRequests = 0
+
+			uaaServer.RouteToHandler("POST", "/oauth/device_authorization",
+				CombineHandlers(
+					VerifyBody([]byte(`client_id=`+config.AuthClient+`&client_secret=`+config.AuthPassword)),
+					RespondWith(http.StatusOK, `{
+						"device_code":"device-code",
+						"user_code":"ABCD-EFGH",
+						"verification_uri":"https://uaa.example.com/device",
+						"verification_uri_complete":"https://uaa.example.com/device?user_code=ABCD-EFGH",
+						"expires_in":600,
+						"interval":1}`),
+				),
+			)
+			uaaServer.RouteToHandler("POST", "/oauth/token",
+				CombineHandlers(
+					VerifyBody([]byte(`client_id=`+config.AuthClient+`&client_secret=`+config.AuthPassword+`&device_code=device-code&grant_type=urn%3Aietf%3Aparams%3Aoauth%3Agrant-type%3Adevice_code`)),
+					func(w http.ResponseWriter, r *http.Request) {
+						tokenRequests++
+						if tokenRequests == 1 {
+							w.WriteHeader(http.StatusBadRequest)
+							w.Write([]byte(`{"error":"authorization_pending"}`))
+							return
+						}
+						w.Write([]byte(`{
+							"access_token":"2YotnFZFEjr1zCsicMWpAA",
+							"refresh_token":"erousflkajqwer",
+							"token_type":"bearer",
+							"expires_in":3600}`))
+					},
+				),
+			)
+
+			setConfigAuthUrl(uaaServer.URL())
+		})
+
+		It("shows the code to enter, waits for approval and saves the tokens", func() {
+			session := runCommand("login", "--device")
+
+			Eventually(session.Out).Should(Say("To log in, visit https://uaa.example.com/device and enter the code: ABCD-EFGH"))
+			Eventually(session.Out).Should(Say(`Or visit https://uaa.example.com/device\?user_code=ABCD-EFGH`))
+			Eventually(session, "10s").Should(Exit(0))
+			Expect(session.Out).To(Say("Login Successful"))
+			Expect(tokenRequests).To(Equal(2))
+
+			cfg := config.ReadConfig()
+			Expect(cfg.AccessToken).To(Equal("2YotnFZFEjr1zCsicMWpAA"))
+			Expect(cfg.RefreshToken).To(Equal("erousflkajqwer"))
+		})
+
+		It("fails when the login is denied", func() {
+			uaaServer.RouteToHandler("POST", "/oauth/token",
+				RespondWith(http.StatusBadRequest, `{"error":"access_denied","error_description":"The user denied the request"}`),
+			)
+
+			session := runCommand("login", "--device")
+
+			Eventually(session, "10s").Should(Exit(1))
+			Expect(session.Err).To(Say("access_denied The user denied the request"))
+		})
+
+		It("cannot be combined with a username", func() {
+			session := runCommand("login", "--device", "--username", "test-username")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Client, password, SSO and/or SSO passcode credentials may not be combined. Please update and retry your request with a single login method."))
+		})
+	})
+
 	Describe("sso flow with server that doesn't give prompt", func() {
 		BeforeEach(func() {
 			uaaServer.RouteToHandler("POST", "/oauth/token",
@@ -508,7 +581,7 @@ var _ = Describe("Login", func() {
 				setupServer(apiServer, uaaServer.URL())
 				session := runCommand("login", "-s", apiServer.URL(), "-u", "user", "-p", "pass")
 
-				Eventually(session).Should(Exit(1))
+				Eventually(session).Should(Exit(7))
 				Eventually(session.Err).Should(Say("Error connecting to the targeted API"))
 			})
 
@@ -542,7 +615,7 @@ var _ = Describe("Login", func() {
 			previousCfg := config.ReadConfig()
 			session := runCommand("login", "-s", server.URL(), "-u", "user", "-p", "pass", "--ca-cert", "../test/auth-tls-ca.pem")
 
-			Eventually(session).Should(Exit(1))
+			Eventually(session).Should(Exit(7))
 			Eventually(session.Err).Should(Say("certificate signed by unknown authority"))
 
 			cfg := config.ReadConfig()
@@ -553,7 +626,7 @@ var _ = Describe("Login", func() {
 			previousCfg := config.ReadConfig()
 			session := runCommand("login", "-s", server.URL(), "-u", "user", "-p", "pass", "--ca-cert", "../test/server-tls-ca.pem")
 
-			Eventually(session).Should(Exit(1))
+			Eventually(session).Should(Exit(7))
 			Eventually(session.Err).Should(Say("certificate signed by unknown authority"))
 
 			cfg := config.ReadConfig()
@@ -595,7 +668,7 @@ var _ = Describe("Login", func() {
 			It("should not login", func() {
 				session := runCommand("login", "-u", "user", "-p", "pass", "-s", badServer.URL())
 
-				Eventually(session).Should(Exit(1))
+				Eventually(session).Should(Exit(7))
 				Eventually(session.Err).Should(Say("Error connecting to the targeted API"))
 				Expect(uaaServer.ReceivedRequests()).Should(HaveLen(0))
 			})
@@ -607,7 +680,7 @@ var _ = Describe("Login", func() {
 
 				session := runCommand("login", "-u", "user", "-p", "pass", "-s", badServer.URL())
 
-				Eventually(session).Should(Exit(1))
+				Eventually(session).Should(Exit(7))
 				Eventually(session.Err).Should(Say("Error connecting to the targeted API"))
 				Expect(uaaServer.ReceivedRequests()).Should(HaveLen(0))
 				cfg2 := config.ReadConfig()
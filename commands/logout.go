@@ -5,7 +5,7 @@ import (
 
 	"code.cloudfoundry.org/credhub-cli/config"
 	"code.cloudfoundry.org/credhub-cli/credhub"
-	"code.cloudfoundry.org/credhub-cli/credhub/auth/uaa"
+	"code.cloudfoundry.org/credhub-cli/credhub/auth/oidc"
 )
 
 type LogoutCommand struct {
@@ -17,7 +17,11 @@ func (c *LogoutCommand) Execute([]string) error {
 		return err
 	}
 	MarkTokensAsRevokedInConfig(&c.config)
-	if err := config.WriteConfig(c.config); err != nil {
+	err := config.UpdateConfig(func(stored *config.Config) error {
+		MarkTokensAsRevokedInConfig(stored)
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 	fmt.Println("Logout Successful")
@@ -25,18 +29,26 @@ func (c *LogoutCommand) Execute([]string) error {
 }
 
 func RevokeTokenIfNecessary(cfg config.Config) error {
-	credhubClient, err := credhub.New(cfg.ApiURL, credhub.CaCerts(cfg.CaCerts...), credhub.SkipTLSValidation(cfg.InsecureSkipVerify))
+	credhubClient, err := credhub.New(cfg.ApiURL, credhub.CaCerts(cfg.CaCerts...), credhub.SkipTLSValidation(cfg.InsecureSkipVerify), credhub.Proxy(cfg.Proxy))
 	if err != nil {
 		return err
 	}
 
-	uaaClient := uaa.Client{
-		AuthURL: cfg.AuthURL,
-		Client:  credhubClient.Client(),
-	}
+	clientId, clientSecret, _ := authClient(cfg)
+	oauthClient := newOAuthClient(cfg, credhubClient.Client(), clientId, clientSecret)
 
 	if cfg.AccessToken != "" && cfg.AccessToken != "revoked" {
-		return uaaClient.RevokeToken(cfg.AccessToken)
+		if err := oauthClient.RevokeToken(cfg.AccessToken); err != nil && err != oidc.ErrRevocationNotSupported {
+			return err
+		}
+	}
+
+	// OpenID Connect providers do not revoke the refresh token along with the
+	// access token
+	if oidcClient, ok := oauthClient.(*oidc.Client); ok && cfg.RefreshToken != "" && cfg.RefreshToken != "revoked" {
+		if err := oidcClient.RevokeRefreshToken(cfg.RefreshToken); err != nil && err != oidc.ErrRevocationNotSupported {
+			return err
+		}
 	}
 
 	return nil
@@ -0,0 +1,125 @@
+package commands_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/credhub-cli/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("OpenID Connect auth server", func() {
+	BeforeEach(func() {
+		SetupServers(server, authServer)
+		authServer.RouteToHandler("GET", "/.well-known/openid-configuration",
+			RespondWith(http.StatusOK, fmt.Sprintf(`{
+				"issuer":"%[1]s",
+				"token_endpoint":"%[1]s/token",
+				"revocation_endpoint":"%[1]s/revoke"
+			}`, authServer.URL())),
+		)
+
+		session := runCommand("api", server.URL(), "--auth-type", "oidc", "--ca-cert", "../test/server-tls-ca.pem", "--ca-cert", "../test/auth-tls-ca.pem")
+		Eventually(session).Should(Exit(0))
+	})
+
+	It("saves the auth type of the API", func() {
+		Expect(config.ReadConfig().AuthType).To(Equal("oidc"))
+	})
+
+	It("rejects unknown auth types", func() {
+		session := runCommand("api", server.URL(), "--auth-type", "ldap")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The auth type 'ldap' is not valid. Valid types include 'uaa' and 'oidc'."))
+	})
+
+	It("logs in with the token endpoint of the discovery document", func() {
+		authServer.RouteToHandler("POST", "/token", CombineHandlers(
+			VerifyForm(map[string][]string{
+				"grant_type": {"password"},
+				"username":   {"test-username"},
+				"password":   {"test-password"},
+				"client_id":  {config.AuthClient},
+			}),
+			RespondWith(http.StatusOK, `{"access_token":"oidc-access-token","refresh_token":"oidc-refresh-token","token_type":"Bearer"}`),
+		))
+
+		session := runCommand("login", "-u", "test-username", "-p", "test-password")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("Login Successful"))
+		cfg := config.ReadConfig()
+		Expect(cfg.AccessToken).To(Equal("oidc-access-token"))
+		Expect(cfg.RefreshToken).To(Equal("oidc-refresh-token"))
+	})
+
+	It("refreshes an expired access token with the token endpoint", func() {
+		cfg := config.ReadConfig()
+		cfg.AccessToken = "expired-access-token"
+		cfg.RefreshToken = "oidc-refresh-token"
+		Expect(config.WriteConfig(cfg)).To(Succeed())
+
+		authServer.RouteToHandler("POST", "/token", CombineHandlers(
+			VerifyForm(map[string][]string{
+				"grant_type":    {"refresh_token"},
+				"refresh_token": {"oidc-refresh-token"},
+			}),
+			RespondWith(http.StatusOK, `{"access_token":"new-access-token","token_type":"Bearer"}`),
+		))
+		server.AppendHandlers(
+			RespondWith(http.StatusUnauthorized, `{"error":"access_token_expired"}`),
+			CombineHandlers(
+				VerifyHeaderKV("Authorization", "Bearer new-access-token"),
+				RespondWith(http.StatusOK, fmt.Sprintf(STRING_CREDENTIAL_ARRAY_RESPONSE_JSON, "value", "my-value", "potatoes")),
+			),
+		)
+
+		session := runCommand("get", "-n", "my-value")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("value: potatoes"))
+	})
+
+	It("does not support the login options of UAA", func() {
+		session := runCommand("login", "--sso-passcode", "passcode")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The --sso-passcode option is not supported when the auth server is an OpenID Connect provider."))
+	})
+
+	It("revokes the access and refresh tokens with the revocation endpoint on logout", func() {
+		cfg := config.ReadConfig()
+		cfg.AccessToken = "oidc-access-token"
+		cfg.RefreshToken = "oidc-refresh-token"
+		Expect(config.WriteConfig(cfg)).To(Succeed())
+
+		authServer.RouteToHandler("POST", "/revoke", CombineHandlers(
+			VerifyForm(map[string][]string{
+				"client_id": {config.AuthClient},
+			}),
+			RespondWith(http.StatusOK, ""),
+		))
+
+		session := runCommand("logout")
+
+		Eventually(session).Should(Exit(0))
+		revoked := map[string]string{}
+		for _, r := range authServer.ReceivedRequests() {
+			if r.URL.Path == "/revoke" {
+				revoked[r.PostForm.Get("token_type_hint")] = r.PostForm.Get("token")
+			}
+		}
+		Expect(revoked).To(Equal(map[string]string{
+			"access_token":  "oidc-access-token",
+			"refresh_token": "oidc-refresh-token",
+		}))
+		Expect(config.ReadConfig().AccessToken).To(Equal("revoked"))
+		Expect(config.ReadConfig().RefreshToken).To(Equal("revoked"))
+	})
+})
@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"code.cloudfoundry.org/credhub-cli/credhub"
+	"code.cloudfoundry.org/credhub-cli/errors"
+)
+
+type OutputCommand struct {
+	OutputJSON bool   `short:"j" long:"output-json" description:"Return response in JSON format"`
+	Output     string `long:"output" description:"Output format. Valid formats include 'yaml', 'json', 'env', 'dotenv', 'raw' and 'template' (Default: yaml)"`
+	Template   string `long:"template" description:"[Template] Go template used to render the response, e.g. '{{.value.certificate}}'"`
+}
+
+var envKeyInvalidChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+func (o OutputCommand) outputFormat() string {
+	if o.Output == "" && o.OutputJSON {
+		return "json"
+	}
+	if o.Output == "" {
+		return "yaml"
+	}
+	return strings.ToLower(o.Output)
+}
+
+// isDefaultOutput is true when no output flags were given, in which case
+// commands print their human readable output
+func (o OutputCommand) isDefaultOutput() bool {
+	return o.Output == "" && !o.OutputJSON
+}
+
+func (o OutputCommand) validateOutput() error {
+	return o.validateOutputWith()
+}
+
+// validateOutputWith also accepts the additional formats, which the command
+// prints itself, e.g. 'csv'
+func (o OutputCommand) validateOutputWith(formats ...string) error {
+	if o.Output != "" && o.OutputJSON {
+		return errors.NewMixedOutputFormatError()
+	}
+
+	for _, format := range formats {
+		if o.outputFormat() == format {
+			return nil
+		}
+	}
+
+	switch o.outputFormat() {
+	case "yaml", "json", "env", "dotenv", "raw":
+		if o.Template != "" {
+			return errors.NewTemplateOnlyValidForTemplateOutputError()
+		}
+	case "template":
+		if o.Template == "" {
+			return errors.NewMissingOutputTemplateError()
+		}
+	default:
+		return errors.NewInvalidOutputFormatError()
+	}
+
+	return nil
+}
+
+func (o OutputCommand) printOutput(v interface{}) error {
+	format := o.outputFormat()
+
+	if format == "yaml" || format == "json" {
+		printCredential(format == "json", v)
+		return nil
+	}
+
+	data, err := toGenericValue(v)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "env":
+		return printEnv(data, "export %s=%s\n", credhub.ShellEscaper)
+	case "dotenv":
+		return printEnv(data, "%s=\"%s\"\n", credhub.JSONEscaper)
+	case "raw":
+		if m, ok := data.(map[string]interface{}); ok && m["value"] != nil {
+			data = m["value"]
+		}
+		s, err := credhub.RawEscaper(data)
+		if err != nil {
+			return err
+		}
+		fmt.Println(s)
+		return nil
+	default:
+		tmpl, err := template.New("output").Option("missingkey=error").Parse(o.Template)
+		if err != nil {
+			return errors.NewInvalidOutputTemplateError(err)
+		}
+		buf := &bytes.Buffer{}
+		if err := tmpl.Execute(buf, data); err != nil {
+			return errors.NewInvalidOutputTemplateError(err)
+		}
+		fmt.Print(buf.String())
+		return nil
+	}
+}
+
+// toGenericValue converts v into the maps, slices and scalars produced by
+// decoding its JSON representation, so that every output format sees the
+// same field names as the JSON output
+func toGenericValue(v interface{}) (interface{}, error) {
+	s, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	dec := json.NewDecoder(bytes.NewReader(s))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func printEnv(data interface{}, lineFormat string, escape credhub.Escaper) error {
+	vars := map[string]interface{}{}
+	flattenEnv("", data, vars)
+
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		value := vars[k]
+		if value == nil {
+			value = ""
+		}
+		if n, ok := value.(json.Number); ok {
+			value = n.String()
+		}
+		s, err := escape(value)
+		if err != nil {
+			return err
+		}
+		fmt.Printf(lineFormat, k, s)
+	}
+
+	return nil
+}
+
+func flattenEnv(prefix string, data interface{}, vars map[string]interface{}) {
+	switch typed := data.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			flattenEnv(envKey(prefix, k), v, vars)
+		}
+	case []interface{}:
+		for i, v := range typed {
+			flattenEnv(envKey(prefix, strconv.Itoa(i)), v, vars)
+		}
+	default:
+		if prefix == "" {
+			prefix = "VALUE"
+		}
+		vars[prefix] = data
+	}
+}
+
+func envKey(prefix, key string) string {
+	key = envKeyInvalidChars.ReplaceAllString(strings.ToUpper(key), "_")
+	if prefix == "" {
+		return key
+	}
+	return prefix + "_" + key
+}
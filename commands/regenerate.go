@@ -1,20 +1,22 @@
 package commands
 
 type RegenerateCommand struct {
-	CredentialIdentifier string `required:"yes" short:"n" long:"name" description:"Selects the credential to regenerate"`
-	OutputJSON           bool   `short:"j" long:"output-json" description:"Return response in JSON format"`
+	CredentialIdentifier CredentialName `required:"yes" short:"n" long:"name" description:"Selects the credential to regenerate"`
+	OutputCommand
 	ClientCommand
 }
 
 func (c *RegenerateCommand) Execute([]string) error {
-	credential, err := c.client.Regenerate(c.CredentialIdentifier)
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
+	credential, err := c.client.Regenerate(string(c.CredentialIdentifier))
 
 	if err != nil {
 		return err
 	}
 
 	credential.Value = "<redacted>"
-	printCredential(c.OutputJSON, credential)
-
-	return nil
+	return c.printOutput(credential)
 }
@@ -66,7 +66,7 @@ var _ = Describe("Regenerate", func() {
 
 			session := runCommand("regenerate", "--name", "my-password-stuffs")
 
-			Eventually(session).Should(Exit(1))
+			Eventually(session).Should(Exit(6))
 			Expect(string(session.Err.Contents())).To(ContainSubstring("The password could not be regenerated because the value was statically set. Only generated passwords may be regenerated."))
 		})
 	})
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
+	"code.cloudfoundry.org/credhub-cli/errors"
+)
+
+type RollbackCommand struct {
+	CredentialIdentifier CredentialName `short:"n" long:"name" required:"yes" description:"Name of the credential to roll back"`
+	ToID                 string         `long:"to-id" description:"ID of the credential version to restore"`
+	Steps                int            `long:"steps" description:"Number of versions to go back (Default: 1)"`
+	Force                bool           `long:"force" description:"Roll back without asking for confirmation"`
+	OutputCommand
+	ClientCommand
+}
+
+type rollbackVersion struct {
+	ID               string `json:"id" yaml:"id"`
+	VersionCreatedAt string `json:"version_created_at" yaml:"version_created_at"`
+	Value            string `json:"value" yaml:"value"`
+}
+
+// rollbackSummary describes a rollback before it is confirmed. Fields marks
+// each field of the value as added, removed, changed or unchanged by the
+// rollback, without showing the values.
+type rollbackSummary struct {
+	Name   string            `json:"name" yaml:"name"`
+	Type   string            `json:"type" yaml:"type"`
+	From   rollbackVersion   `json:"from" yaml:"from"`
+	To     rollbackVersion   `json:"to" yaml:"to"`
+	Fields map[string]string `json:"fields" yaml:"fields"`
+}
+
+func (c *RollbackCommand) Execute([]string) error {
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
+	if c.ToID != "" && c.Steps != 0 {
+		return errors.NewMixedRollbackParametersError()
+	}
+	if c.Steps < 0 {
+		return errors.NewInvalidRollbackStepsError()
+	}
+
+	current, target, err := c.findVersions()
+	if err != nil {
+		return err
+	}
+
+	if current.Id == target.Id {
+		return errors.NewRollbackToCurrentVersionError()
+	}
+
+	if !c.Force {
+		err := c.printOutput(rollbackSummary{
+			Name:   current.Name,
+			Type:   target.Type,
+			From:   rollbackVersion{ID: current.Id, VersionCreatedAt: current.VersionCreatedAt, Value: "<redacted>"},
+			To:     rollbackVersion{ID: target.Id, VersionCreatedAt: target.VersionCreatedAt, Value: "<redacted>"},
+			Fields: fieldChanges(settableValue(current), settableValue(target)),
+		})
+		if err != nil {
+			return err
+		}
+
+		var answer string
+		promptForInput("Are you sure you want to roll back this credential? [y/N]: ", &answer)
+		if answer != "y" && answer != "yes" {
+			return errors.NewRollbackAbortedError()
+		}
+	}
+
+	credential, err := c.client.SetCredential(current.Name, target.Type, settableValue(target))
+	if err != nil {
+		return err
+	}
+
+	credential.Value = "<redacted>"
+	return c.printOutput(credential)
+}
+
+func (c *RollbackCommand) findVersions() (credentials.Credential, credentials.Credential, error) {
+	if c.ToID != "" {
+		current, err := c.client.GetLatestVersion(string(c.CredentialIdentifier))
+		if err != nil {
+			return current, credentials.Credential{}, err
+		}
+
+		target, err := c.client.GetById(c.ToID)
+		if err != nil {
+			return current, target, err
+		}
+
+		if strings.TrimPrefix(target.Name, "/") != strings.TrimPrefix(current.Name, "/") {
+			return current, target, errors.NewRollbackVersionMismatchError(c.ToID, current.Name)
+		}
+
+		return current, target, nil
+	}
+
+	steps := c.Steps
+	if steps == 0 {
+		steps = 1
+	}
+
+	versions, err := c.client.GetNVersions(string(c.CredentialIdentifier), steps+1)
+	if err != nil {
+		return credentials.Credential{}, credentials.Credential{}, err
+	}
+
+	if len(versions) <= steps {
+		return credentials.Credential{}, credentials.Credential{}, errors.NewNotEnoughVersionsError(steps, len(versions))
+	}
+
+	return versions[0], versions[steps], nil
+}
+
+// settableValue removes the fields that CredHub computes from a credential
+// value, so that the value can be set again
+func settableValue(cred credentials.Credential) interface{} {
+	value, ok := cred.Value.(map[string]interface{})
+	if !ok {
+		return cred.Value
+	}
+
+	computed := map[string][]string{
+		"user": {"password_hash"},
+		"ssh":  {"public_key_fingerprint"},
+	}
+
+	result := map[string]interface{}{}
+	for k, v := range value {
+		result[k] = v
+	}
+	for _, field := range computed[cred.Type] {
+		delete(result, field)
+	}
+
+	return result
+}
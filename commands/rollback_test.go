@@ -0,0 +1,189 @@
+package commands_test
+
+import (
+	"bytes"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+const OLD_UUID = `8f2a1b3c-1686-4c8d-80eb-5daa866f9f86`
+
+const ROLLBACK_VERSIONS_RESPONSE_JSON = `{"data":[
+	{"type":"user","id":"` + UUID + `","name":"/my-user","version_created_at":"2017-02-01T12:00:00Z","value":{"username":"new-user","password":"new-password","password_hash":"new-hash"}},
+	{"type":"user","id":"` + OLD_UUID + `","name":"/my-user","version_created_at":"2017-01-01T12:00:00Z","value":{"username":"old-user","password":"old-password","password_hash":"old-hash"}}
+]}`
+
+const ROLLBACK_SET_RESPONSE_JSON = `{"type":"user","id":"9b3c2d4e-1686-4c8d-80eb-5daa866f9f86","name":"/my-user","version_created_at":"2017-03-01T12:00:00Z","value":{"username":"old-user","password":"old-password","password_hash":"old-hash"}}`
+
+var _ = Describe("Rollback", func() {
+	BeforeEach(func() {
+		login()
+	})
+
+	ItRequiresAuthentication("rollback", "-n", "test-credential")
+	ItRequiresAnAPIToBeSet("rollback", "-n", "test-credential")
+
+	Describe("Help", func() {
+		ItBehavesLikeHelp("rollback", "rollback", func(session *Session) {
+			Expect(session.Err).To(Say("rollback"))
+			Expect(session.Err).To(Say("to-id"))
+		})
+	})
+
+	Context("when going back a number of versions", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				CombineHandlers(
+					VerifyRequest("GET", "/api/v1/data", "name=/my-user&versions=2"),
+					RespondWith(http.StatusOK, ROLLBACK_VERSIONS_RESPONSE_JSON),
+				),
+			)
+		})
+
+		It("sets the previous value as a new version after confirmation", func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest("PUT", "/api/v1/data"),
+					VerifyJSON(`{"name":"/my-user","type":"user","value":{"username":"old-user","password":"old-password"}}`),
+					RespondWith(http.StatusOK, ROLLBACK_SET_RESPONSE_JSON),
+				),
+			)
+
+			session := runCommandWithStdin(bytes.NewBufferString("y\n"), "rollback", "-n", "/my-user")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("name: /my-user"))
+			Expect(session.Out).To(Say("type: user"))
+			Expect(session.Out).To(Say("from:\n  id: " + UUID + "\n  version_created_at: \"2017-02-01T12:00:00Z\"\n  value: <redacted>"))
+			Expect(session.Out).To(Say("to:\n  id: " + OLD_UUID + "\n  version_created_at: \"2017-01-01T12:00:00Z\"\n  value: <redacted>"))
+			Expect(session.Out).To(Say("fields:\n  password: changed\n  username: changed"))
+			Expect(session.Out).To(Say(`Are you sure you want to roll back this credential\? \[y/N\]:`))
+			Expect(session.Out).To(Say("id: 9b3c2d4e-1686-4c8d-80eb-5daa866f9f86"))
+			Expect(session.Out).To(Say("value: <redacted>"))
+			Expect(session.Out.Contents()).NotTo(ContainSubstring("old-password"))
+			Expect(session.Out.Contents()).NotTo(ContainSubstring("new-password"))
+		})
+
+		It("prints the confirmation summary in the selected output format", func() {
+			session := runCommandWithStdin(bytes.NewBufferString("n\n"), "rollback", "-n", "/my-user", "-j")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Out).To(Say(`"name": "/my-user"`))
+			Expect(session.Out).To(Say(`"from": {\s+"id": "` + UUID + `"`))
+			Expect(session.Out).To(Say(`"to": {\s+"id": "` + OLD_UUID + `"`))
+			Expect(session.Out).To(Say(`Are you sure you want to roll back this credential\? \[y/N\]:`))
+		})
+
+		It("marks the fields that the rollback leaves unchanged", func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				RespondWith(http.StatusOK, `{"data":[
+					{"type":"user","id":"`+UUID+`","name":"/my-user","version_created_at":"2017-02-01T12:00:00Z","value":{"username":"some-user","password":"new-password","password_hash":"new-hash"}},
+					{"type":"user","id":"`+OLD_UUID+`","name":"/my-user","version_created_at":"2017-01-01T12:00:00Z","value":{"username":"some-user","password":"old-password","password_hash":"old-hash"}}
+				]}`),
+			)
+
+			session := runCommandWithStdin(bytes.NewBufferString("n\n"), "rollback", "-n", "/my-user")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Out).To(Say("fields:\n  password: changed\n  username: unchanged"))
+			Expect(session.Out.Contents()).NotTo(ContainSubstring("some-user"))
+			Expect(session.Out.Contents()).NotTo(ContainSubstring("password_hash"))
+		})
+
+		It("does not set anything when the rollback is not confirmed", func() {
+			session := runCommandWithStdin(bytes.NewBufferString("n\n"), "rollback", "-n", "/my-user")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Rollback aborted."))
+			for _, request := range server.ReceivedRequests() {
+				Expect(request.Method).NotTo(Equal("PUT"))
+			}
+		})
+
+		It("does not ask for confirmation with --force", func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest("PUT", "/api/v1/data"),
+					RespondWith(http.StatusOK, ROLLBACK_SET_RESPONSE_JSON),
+				),
+			)
+
+			session := runCommand("rollback", "-n", "/my-user", "--force", "-j")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out.Contents()).To(MatchJSON(`{
+				"id": "9b3c2d4e-1686-4c8d-80eb-5daa866f9f86",
+				"name": "/my-user",
+				"type": "user",
+				"version_created_at": "2017-03-01T12:00:00Z",
+				"value": "<redacted>"
+			}`))
+		})
+
+		It("returns an error when there are not enough versions", func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				CombineHandlers(
+					VerifyRequest("GET", "/api/v1/data", "name=/my-user&versions=4"),
+					RespondWith(http.StatusOK, ROLLBACK_VERSIONS_RESPONSE_JSON),
+				),
+			)
+
+			session := runCommand("rollback", "-n", "/my-user", "--steps", "3", "--force")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(`Cannot roll back 3 version\(s\): only 2 version\(s\) of the credential exist.`))
+		})
+	})
+
+	Context("when restoring a version by ID", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				CombineHandlers(
+					VerifyRequest("GET", "/api/v1/data", "current=true&name=my-user"),
+					RespondWith(http.StatusOK, `{"data":[{"type":"user","id":"`+UUID+`","name":"/my-user","version_created_at":"2017-02-01T12:00:00Z","value":{"username":"new-user","password":"new-password","password_hash":"new-hash"}}]}`),
+				),
+			)
+		})
+
+		It("sets the value of that version", func() {
+			server.RouteToHandler("GET", "/api/v1/data/"+OLD_UUID,
+				RespondWith(http.StatusOK, `{"type":"user","id":"`+OLD_UUID+`","name":"/my-user","version_created_at":"2017-01-01T12:00:00Z","value":{"username":"old-user","password":"old-password","password_hash":"old-hash"}}`),
+			)
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest("PUT", "/api/v1/data"),
+					VerifyJSON(`{"name":"/my-user","type":"user","value":{"username":"old-user","password":"old-password"}}`),
+					RespondWith(http.StatusOK, ROLLBACK_SET_RESPONSE_JSON),
+				),
+			)
+
+			session := runCommand("rollback", "-n", "my-user", "--to-id", OLD_UUID, "--force")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("value: <redacted>"))
+		})
+
+		It("returns an error when the version belongs to another credential", func() {
+			server.RouteToHandler("GET", "/api/v1/data/"+OLD_UUID,
+				RespondWith(http.StatusOK, `{"type":"password","id":"`+OLD_UUID+`","name":"/other","version_created_at":"2017-01-01T12:00:00Z","value":"other"}`),
+			)
+
+			session := runCommand("rollback", "-n", "my-user", "--to-id", OLD_UUID, "--force")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The version '" + OLD_UUID + "' does not belong to the credential '/my-user'."))
+		})
+	})
+
+	It("does not allow --to-id and --steps together", func() {
+		session := runCommand("rollback", "-n", "/my-user", "--to-id", OLD_UUID, "--steps", "2")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The --to-id flag and --steps flag are incompatible"))
+	})
+})
@@ -0,0 +1,228 @@
+package commands
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/credhub-cli/errors"
+)
+
+// rotateTypes are the types of credentials that can be regenerated
+var rotateTypes = []string{"password", "user", "ssh", "rsa", "certificate"}
+
+type RotateCommand struct {
+	Path        CredentialPath `short:"p" long:"path" required:"yes" description:"Path of credentials to rotate"`
+	Types       string         `short:"t" long:"type" description:"Comma-separated types of credentials to rotate (Default: password,user,ssh,rsa,certificate)"`
+	OlderThan   string         `long:"older-than" description:"Only rotate credentials whose current version is older than the provided age, e.g. '90d' or '12h'"`
+	DryRun      bool           `long:"dry-run" description:"Report the credentials that would be rotated without regenerating them"`
+	Concurrency int            `long:"concurrency" default:"5" description:"Number of credentials regenerated at the same time"`
+	OutputCommand
+	ClientCommand
+}
+
+type rotateReport struct {
+	DryRun      bool                `json:"dry_run" yaml:"dry_run"`
+	Credentials []rotatedCredential `json:"credentials" yaml:"credentials"`
+}
+
+type rotatedCredential struct {
+	Name         string `json:"name" yaml:"name"`
+	Type         string `json:"type" yaml:"type"`
+	OldVersionId string `json:"old_version_id" yaml:"old_version_id"`
+	NewVersionId string `json:"new_version_id,omitempty" yaml:"new_version_id,omitempty"`
+	Error        string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (c *RotateCommand) Execute([]string) error {
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
+	types, err := parseRotateTypes(c.Types)
+	if err != nil {
+		return err
+	}
+
+	var olderThan time.Duration
+	if c.OlderThan != "" {
+		if olderThan, err = parseAge(c.OlderThan); err != nil {
+			return err
+		}
+	}
+
+	if c.Concurrency < 1 {
+		return errors.NewInvalidRotateConcurrencyError()
+	}
+
+	results, err := c.client.FindByPath(string(c.Path))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var names []string
+	for _, cred := range results.Credentials {
+		if olderThan == 0 || isOlderThan(cred.VersionCreatedAt, olderThan, now) {
+			names = append(names, cred.Name)
+		}
+	}
+	sort.Strings(names)
+
+	metadata, err := getLatestMetadata(c.client, names)
+	if err != nil {
+		return err
+	}
+
+	report := rotateReport{DryRun: c.DryRun, Credentials: []rotatedCredential{}}
+	for _, name := range names {
+		cred, ok := metadata[name]
+		if !ok || !types[cred.Type] {
+			continue
+		}
+		report.Credentials = append(report.Credentials, rotatedCredential{
+			Name:         cred.Name,
+			Type:         cred.Type,
+			OldVersionId: cred.Id,
+		})
+	}
+
+	if c.DryRun {
+		return c.printOutput(report)
+	}
+
+	values, err := c.certificateValues(report.Credentials)
+	if err != nil {
+		return err
+	}
+
+	rotated := report.Credentials
+	for _, wave := range signingWaves(values) {
+		forEachConcurrently(len(wave), c.Concurrency, func(i int) {
+			cred, err := c.client.Regenerate(rotated[wave[i]].Name)
+			if err != nil {
+				rotated[wave[i]].Error = err.Error()
+			} else {
+				rotated[wave[i]].NewVersionId = cred.Id
+			}
+		})
+	}
+
+	if err := c.printOutput(report); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, cred := range rotated {
+		if cred.Error != "" {
+			failed++
+		}
+	}
+	if failed != 0 {
+		return errors.NewRotateFailedError(failed, len(rotated))
+	}
+
+	return nil
+}
+
+// certificateValues gets the values of the certificates among creds, in the
+// same order, which are needed to regenerate CAs before the certificates they
+// sign. The values of the other credentials are nil.
+func (c *RotateCommand) certificateValues(creds []rotatedCredential) ([]interface{}, error) {
+	var (
+		indexes []int
+		names   []string
+	)
+	for i, cred := range creds {
+		if cred.Type == "certificate" {
+			indexes = append(indexes, i)
+			names = append(names, cred.Name)
+		}
+	}
+
+	current, err := getLatestVersions(c.client, names)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(creds))
+	for j, i := range indexes {
+		values[i] = current[j].Value
+	}
+
+	return values, nil
+}
+
+// parseRotateTypes parses a comma-separated list of types, which defaults to
+// all types that can be regenerated
+func parseRotateTypes(value string) (map[string]bool, error) {
+	names := rotateTypes
+	if value != "" {
+		names = strings.Split(value, ",")
+	}
+
+	types := map[string]bool{}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		valid := false
+		for _, t := range rotateTypes {
+			valid = valid || name == t
+		}
+		if !valid {
+			return nil, errors.NewInvalidRotateTypeError(name)
+		}
+
+		types[name] = true
+	}
+
+	return types, nil
+}
+
+// signingWaves groups the indexes of the credential values by their depth in
+// the signing chains among them, so that each CA is regenerated before the
+// certificates it signs. A certificate is signed by the credential whose
+// certificate is its CA.
+func signingWaves(values []interface{}) [][]int {
+	signers := make([]int, len(values))
+	for i := range values {
+		signers[i] = -1
+
+		ca := certificateField(values[i], "ca")
+		if ca == "" {
+			continue
+		}
+		for j := range values {
+			if j != i && certificateField(values[j], "certificate") == ca {
+				signers[i] = j
+				break
+			}
+		}
+	}
+
+	var waves [][]int
+	for i := range values {
+		depth := 0
+		for j := signers[i]; j != -1 && depth < len(values); j = signers[j] {
+			depth++
+		}
+
+		for len(waves) <= depth {
+			waves = append(waves, nil)
+		}
+		waves[depth] = append(waves[depth], i)
+	}
+
+	return waves
+}
+
+// certificateField returns a PEM field of a certificate credential value, or
+// an empty string for other values
+func certificateField(value interface{}, field string) string {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	pem, _ := fields[field].(string)
+	return strings.TrimSpace(pem)
+}
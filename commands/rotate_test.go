@@ -0,0 +1,149 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Rotate", func() {
+	var (
+		regenerated []string
+		mutex       sync.Mutex
+	)
+
+	BeforeEach(func() {
+		login()
+
+		regenerated = nil
+		current := map[string]string{
+			"/cf/db_password": `"type":"password","version_created_at":"2017-01-01T00:00:00Z"`,
+			"/cf/admin":       `"type":"user","version_created_at":"2017-01-01T00:00:00Z"`,
+			"/cf/config":      `"type":"json","version_created_at":"2017-01-01T00:00:00Z"`,
+			"/cf/new_cert":    `"type":"certificate","version_created_at":"2100-01-01T00:00:00Z"`,
+		}
+
+		server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("path") == "/cf" {
+				fmt.Fprint(w, `{"credentials":[
+					{"name":"/cf/db_password","version_created_at":"2017-01-01T00:00:00Z"},
+					{"name":"/cf/admin","version_created_at":"2017-01-01T00:00:00Z"},
+					{"name":"/cf/config","version_created_at":"2017-01-01T00:00:00Z"},
+					{"name":"/cf/new_cert","version_created_at":"2100-01-01T00:00:00Z"}
+				]}`)
+				return
+			}
+
+			name := r.URL.Query().Get("name")
+			fmt.Fprintf(w, `{"data":[{"id":"old-id%s","name":"%s",%s,"value":"redacted"}]}`, name, name, current[name])
+		})
+
+		server.RouteToHandler("POST", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			var request struct {
+				Name       string `json:"name"`
+				Regenerate bool   `json:"regenerate"`
+			}
+			json.Unmarshal(body, &request)
+			Expect(request.Regenerate).To(BeTrue())
+
+			mutex.Lock()
+			regenerated = append(regenerated, request.Name)
+			mutex.Unlock()
+
+			if request.Name == "/cf/admin" {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"error":"The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`)
+				return
+			}
+			fmt.Fprintf(w, `{"id":"new-id%s","name":"%s","type":"password","value":"new-value"}`, request.Name, request.Name)
+		})
+	})
+
+	ItRequiresAuthentication("rotate", "-p", "/cf")
+	ItRequiresAnAPIToBeSet("rotate", "-p", "/cf")
+
+	Describe("Help", func() {
+		ItBehavesLikeHelp("rotate", "rotate", func(session *Session) {
+			Expect(session.Err).To(Say("rotate"))
+			Expect(session.Err).To(Say("dry-run"))
+		})
+	})
+
+	It("regenerates the selected credentials and reports their old and new versions", func() {
+		session := runCommand("rotate", "-p", "/cf", "--type", "password", "-j")
+
+		Eventually(session).Should(Exit(0))
+		Expect(regenerated).To(Equal([]string{"/cf/db_password"}))
+		Expect(session.Out.Contents()).To(MatchJSON(`{
+			"dry_run": false,
+			"credentials": [
+				{"name":"/cf/db_password","type":"password","old_version_id":"old-id/cf/db_password","new_version_id":"new-id/cf/db_password"}
+			]
+		}`))
+	})
+
+	It("reports the credentials that would be rotated without regenerating them", func() {
+		session := runCommand("rotate", "-p", "/cf", "--dry-run", "--older-than", "90d", "-j")
+
+		Eventually(session).Should(Exit(0))
+		Expect(regenerated).To(BeEmpty())
+		Expect(session.Out.Contents()).To(MatchJSON(`{
+			"dry_run": true,
+			"credentials": [
+				{"name":"/cf/admin","type":"user","old_version_id":"old-id/cf/admin"},
+				{"name":"/cf/db_password","type":"password","old_version_id":"old-id/cf/db_password"}
+			]
+		}`))
+	})
+
+	It("rotates the other credentials when one fails and exits with an error", func() {
+		session := runCommand("rotate", "-p", "/cf", "--concurrency", "1", "-j")
+
+		Eventually(session).Should(Exit(1))
+		Expect(regenerated).To(ConsistOf("/cf/admin", "/cf/db_password", "/cf/new_cert"))
+		Expect(session.Out).To(Say(`"error": "The request could not be completed`))
+		Expect(session.Out).To(Say(`"new_version_id": "new-id/cf/db_password"`))
+		Expect(session.Err).To(Say("1 of 3 credentials could not be rotated."))
+	})
+
+	It("rejects types that cannot be regenerated", func() {
+		session := runCommand("rotate", "-p", "/cf", "--type", "password,json")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The type 'json' cannot be rotated."))
+	})
+
+	It("regenerates CAs before the certificates they sign", func() {
+		certificates := map[string]string{
+			"/certs/a_leaf":         `{"ca":"intermediate-pem","certificate":"leaf-pem"}`,
+			"/certs/b_intermediate": `{"ca":"root-pem","certificate":"intermediate-pem"}`,
+			"/certs/c_root":         `{"ca":"root-pem","certificate":"root-pem"}`,
+		}
+		server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("path") == "/certs" {
+				fmt.Fprint(w, `{"credentials":[
+					{"name":"/certs/a_leaf","version_created_at":"2017-01-01T00:00:00Z"},
+					{"name":"/certs/b_intermediate","version_created_at":"2017-01-01T00:00:00Z"},
+					{"name":"/certs/c_root","version_created_at":"2017-01-01T00:00:00Z"}
+				]}`)
+				return
+			}
+
+			name := r.URL.Query().Get("name")
+			fmt.Fprintf(w, `{"data":[{"id":"old-id%s","name":"%s","type":"certificate","version_created_at":"2017-01-01T00:00:00Z","value":%s}]}`, name, name, certificates[name])
+		})
+
+		session := runCommand("rotate", "-p", "/certs", "--concurrency", "1", "-j")
+
+		Eventually(session).Should(Exit(0))
+		Expect(regenerated).To(Equal([]string{"/certs/c_root", "/certs/b_intermediate", "/certs/a_leaf"}))
+	})
+})
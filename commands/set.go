@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"code.cloudfoundry.org/credhub-cli/credhub"
 	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
 	"code.cloudfoundry.org/credhub-cli/credhub/credentials/values"
 	"code.cloudfoundry.org/credhub-cli/errors"
@@ -15,17 +16,20 @@ import (
 )
 
 type SetCommand struct {
-	CredentialIdentifier string `short:"n" required:"yes" long:"name" description:"Name of the credential to set"`
-	Type                 string `short:"t" long:"type" description:"Sets the credential type. Valid types include 'value', 'json', 'password', 'user', 'certificate', 'ssh' and 'rsa'."`
-	Value                string `short:"v" long:"value" description:"[Value, JSON] Sets the value for the credential"`
-	CaName               string `short:"m" long:"ca-name" description:"[Certificate] Sets the root CA to a stored CA credential"`
-	Root                 string `short:"r" long:"root" description:"[Certificate] Sets the root CA from file or value"`
-	Certificate          string `short:"c" long:"certificate" description:"[Certificate] Sets the certificate from file or value"`
-	Private              string `short:"p" long:"private" description:"[Certificate, SSH, RSA] Sets the private key from file or value"`
-	Public               string `short:"u" long:"public" description:"[SSH, RSA] Sets the public key from file or value"`
-	Username             string `short:"z" long:"username" description:"[User] Sets the username value of the credential"`
-	Password             string `short:"w" long:"password" description:"[Password, User] Sets the password value of the credential"`
-	OutputJSON           bool   `short:"j" long:"output-json" description:"Return response in JSON format"`
+	CredentialIdentifier CredentialName `short:"n" required:"yes" long:"name" description:"Name of the credential to set"`
+	Type                 string         `short:"t" long:"type" description:"Sets the credential type. Valid types include 'value', 'json', 'password', 'user', 'certificate', 'ssh' and 'rsa'."`
+	Value                string         `short:"v" long:"value" description:"[Value, JSON] Sets the value for the credential"`
+	CaName               string         `short:"m" long:"ca-name" description:"[Certificate] Sets the root CA to a stored CA credential"`
+	Root                 string         `short:"r" long:"root" description:"[Certificate] Sets the root CA from file or value"`
+	Certificate          string         `short:"c" long:"certificate" description:"[Certificate] Sets the certificate from file or value"`
+	Private              string         `short:"p" long:"private" description:"[Certificate, SSH, RSA] Sets the private key from file or value"`
+	Public               string         `short:"u" long:"public" description:"[SSH, RSA] Sets the public key from file or value"`
+	Username             string         `short:"z" long:"username" description:"[User] Sets the username value of the credential"`
+	Password             string         `short:"w" long:"password" description:"[Password, User] Sets the password value of the credential"`
+	Merge                string         `long:"merge" description:"[JSON] Merges the provided JSON object into the stored value. Fields set to null are removed"`
+	Patch                string         `long:"patch" description:"[JSON] Applies the JSON patch operations (RFC 6902) from file or value to the stored value"`
+	IfVersion            string         `long:"if-version" description:"Sets the credential only if the ID of its current version matches the provided ID"`
+	OutputCommand
 	ClientCommand
 }
 
@@ -36,6 +40,17 @@ func (c *SetCommand) Execute([]string) error {
 		return errors.NewSetEmptyTypeError()
 	}
 
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
+	if c.Merge != "" || c.Patch != "" {
+		if c.IfVersion != "" {
+			return errors.NewIfVersionWithJSONUpdateError()
+		}
+		return c.updateJSON()
+	}
+
 	c.setFieldsFromInteractiveUserInput()
 
 	err := c.setFieldsFromFileOrString()
@@ -49,9 +64,7 @@ func (c *SetCommand) Execute([]string) error {
 	}
 
 	credential.Value = "<redacted>"
-	printCredential(c.OutputJSON, credential)
-
-	return nil
+	return c.printOutput(credential)
 }
 
 func (c *SetCommand) setFieldsFromInteractiveUserInput() {
@@ -126,7 +139,70 @@ func (c *SetCommand) setCredential() (credentials.Credential, error) {
 	default:
 		value = values.Value(c.Value)
 	}
-	return c.client.SetCredential(c.CredentialIdentifier, c.Type, value)
+
+	if c.IfVersion != "" {
+		return c.client.SetCredentialIfVersion(string(c.CredentialIdentifier), c.Type, value, c.IfVersion)
+	}
+	return c.client.SetCredential(string(c.CredentialIdentifier), c.Type, value)
+}
+
+// updateJSON merges or patches the stored value of a JSON credential
+func (c *SetCommand) updateJSON() error {
+	if c.Type != "json" {
+		return errors.NewJSONUpdateTypeError()
+	}
+	if c.Merge != "" && c.Patch != "" || c.hasValueFlags() {
+		return errors.NewMixedJSONUpdateParametersError()
+	}
+
+	var (
+		credential credentials.JSON
+		err        error
+	)
+
+	if c.Merge != "" {
+		patch := values.JSON{}
+		if err := json.Unmarshal([]byte(c.Merge), &patch); err != nil {
+			return errors.NewInvalidJSONUpdateError(err)
+		}
+		credential, err = c.client.MergeJSON(string(c.CredentialIdentifier), patch)
+	} else {
+		var operations []credhub.JSONPatchOperation
+		if operations, err = readJSONPatch(c.Patch); err != nil {
+			return err
+		}
+		credential, err = c.client.PatchJSON(string(c.CredentialIdentifier), operations)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.printOutput(credentials.Credential{Metadata: credential.Metadata, Value: "<redacted>"})
+}
+
+// hasValueFlags is true when any of the flags that set the value of the
+// credential were given
+func (c *SetCommand) hasValueFlags() bool {
+	for _, value := range []string{c.Value, c.CaName, c.Root, c.Certificate, c.Private, c.Public, c.Username, c.Password} {
+		if value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func readJSONPatch(field string) ([]credhub.JSONPatchOperation, error) {
+	patch, err := util.ReadFileOrStringFromField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []credhub.JSONPatchOperation
+	if err := json.Unmarshal([]byte(patch), &operations); err != nil {
+		return nil, errors.NewInvalidJSONUpdateError(err)
+	}
+
+	return operations, nil
 }
 
 func promptForInput(prompt string, value *string) {
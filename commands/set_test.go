@@ -2,8 +2,10 @@ package commands_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 
@@ -130,6 +132,119 @@ var _ = Describe("Set", func() {
 		})
 	})
 
+	Describe("updating json secrets", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				CombineHandlers(
+					VerifyRequest("GET", "/api/v1/data", "current=true&name=json-secret"),
+					RespondWith(http.StatusOK, `{"data":[`+fmt.Sprintf(JSON_CREDENTIAL_RESPONSE_JSON, "json-secret", `{"foo":"bar","nested":{"a":1,"b":2}}`)+`]}`),
+				),
+			)
+		})
+
+		It("merges the provided JSON into the stored value", func() {
+			setupPutJsonServer("json-secret", `{"nested":{"a":1,"b":3}}`)
+
+			session := runCommand("set", "-n", "json-secret", "-t", "json", "--merge", `{"foo":null,"nested":{"b":3}}`)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("name: json-secret"))
+			Expect(session.Out).To(Say("type: json"))
+			Expect(session.Out).To(Say("value: <redacted>"))
+		})
+
+		It("applies JSON patch operations read from a file", func() {
+			setupPutJsonServer("json-secret", `{"foo":"baz","nested":{"a":1,"b":2}}`)
+			patchFile := filepath.Join(homeDir, "ops.json")
+			ioutil.WriteFile(patchFile, []byte(`[{"op":"test","path":"/foo","value":"bar"},{"op":"replace","path":"/foo","value":"baz"}]`), 0600)
+
+			session := runCommand("set", "-n", "json-secret", "-t", "json", "--patch", patchFile)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("value: <redacted>"))
+		})
+
+		It("does not set anything when a patch operation fails", func() {
+			session := runCommand("set", "-n", "json-secret", "-t", "json", "--patch", `[{"op":"test","path":"/foo","value":"other"}]`)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("test failed: the value at '/foo' does not match"))
+			for _, request := range server.ReceivedRequests() {
+				Expect(request.Method).NotTo(Equal("PUT"))
+			}
+		})
+
+		It("requires the json type", func() {
+			session := runCommand("set", "-n", "json-secret", "-t", "value", "--merge", `{"foo":"baz"}`)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The merge and patch flags can only be used with the type 'json'."))
+		})
+
+		It("cannot be combined with a value", func() {
+			session := runCommand("set", "-n", "json-secret", "-t", "json", "-v", `{}`, "--merge", `{"foo":"baz"}`)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The merge and patch flags cannot be combined with each other or with the flags that set the value"))
+		})
+
+		It("cannot be combined with the other flags that set a value", func() {
+			session := runCommand("set", "-n", "json-secret", "-t", "json", "--password", "secret", "--patch", `[{"op":"remove","path":"/foo"}]`)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The merge and patch flags cannot be combined with each other or with the flags that set the value"))
+
+			session = runCommand("set", "-n", "json-secret", "-t", "json", "--certificate", "cert", "--merge", `{"foo":"baz"}`)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The merge and patch flags cannot be combined with each other or with the flags that set the value"))
+		})
+
+		It("rejects a merge that is not a JSON object", func() {
+			session := runCommand("set", "-n", "json-secret", "-t", "json", "--merge", `[1]`)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The provided merge or patch could not be parsed"))
+		})
+	})
+
+	Describe("setting secrets only if the version matches", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				CombineHandlers(
+					VerifyRequest("GET", "/api/v1/data", "current=true&name=my-value"),
+					RespondWith(http.StatusOK, `{"data":[`+fmt.Sprintf(STRING_CREDENTIAL_RESPONSE_JSON, "value", "my-value", "old-value")+`]}`),
+				),
+			)
+		})
+
+		It("sets the secret when the current version matches", func() {
+			SetupPutValueServer("my-value", "value", "new-value")
+
+			session := runCommand("set", "-n", "my-value", "-t", "value", "-v", "new-value", "--if-version", UUID)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("name: my-value"))
+		})
+
+		It("does not set the secret when the current version is a different one", func() {
+			session := runCommand("set", "-n", "my-value", "-t", "value", "-v", "new-value", "--if-version", "some-other-id")
+
+			Eventually(session).Should(Exit(5))
+			Expect(session.Err).To(Say("The credential 'my-value' was changed by another client: expected version 'some-other-id' but the current version is '" + UUID + "'. Please retry your request."))
+			for _, request := range server.ReceivedRequests() {
+				Expect(request.Method).NotTo(Equal("PUT"))
+			}
+		})
+
+		It("cannot be combined with a merge", func() {
+			session := runCommand("set", "-n", "my-value", "-t", "json", "--merge", `{}`, "--if-version", UUID)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The if-version flag cannot be combined with the merge or patch flags"))
+		})
+	})
+
 	Describe("setting SSH secrets", func() {
 		It("puts a secret using explicit ssh type", func() {
 			SetupPutSshServer("foo-ssh-key", "ssh", "some-public-key", "some-private-key")
@@ -523,7 +638,7 @@ var _ = Describe("Set", func() {
 
 			session := runCommand("set", "-n", "my-value", "-t", "value", "-v", "tomatoes")
 
-			Eventually(session).Should(Exit(1))
+			Eventually(session).Should(Exit(6))
 
 			Expect(session.Err).To(Say("test error"))
 		})
@@ -14,10 +14,14 @@ import (
 	"os"
 	"time"
 
+	"code.cloudfoundry.org/credhub-cli/config"
 	"github.com/armon/go-socks5"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
 	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+	"golang.org/x/net/context"
 )
 
 var _ = Describe("SOCKS5 support", func() {
@@ -59,7 +63,52 @@ var _ = Describe("SOCKS5 support", func() {
 		Expect(proxyListener.Close()).To(Succeed())
 
 		session = runCommand("api", "https://"+OutboundServerAddress(), "--ca-cert", certPath)
+		Eventually(session).Should(Exit(7))
+	})
+
+	It("uses the proxy provided for the target", func() {
+		proxyListener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		defer proxyListener.Close()
+
+		proxied := make(chan string, 100)
+		go func() {
+			proxyServer, err := socks5.New(&socks5.Config{
+				Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					proxied <- addr
+					return net.Dial(network, addr)
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			proxyServer.Serve(proxyListener)
+		}()
+
+		server.RouteToHandler("GET", "/info", RespondWith(http.StatusOK, `{"auth-server":{"url":"`+authServer.URL()+`"}}`))
+		authServer.RouteToHandler("GET", "/info", RespondWith(http.StatusOK, ""))
+
+		session := runCommand("api", server.URL(), "--ca-cert", "../test/server-tls-ca.pem", "--ca-cert", "../test/auth-tls-ca.pem", "--proxy", "socks5://"+proxyListener.Addr().String())
+		Eventually(session).Should(Exit(0))
+		Expect(config.ReadConfig().Proxy).To(Equal("socks5://" + proxyListener.Addr().String()))
+		Expect(proxied).To(Receive(Equal(server.Addr())))
+	})
+
+	It("fails instead of connecting without the proxy when the proxy is not valid", func() {
+		session := runCommandWithEnv([]string{"BOSH_ALL_PROXY=ssh+socks5://jumpbox@localhost:22"}, "api", server.URL(), "--ca-cert", "../test/server-tls-ca.pem")
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("invalid proxy: the 'private-key' parameter of an SSH proxy is required"))
+
+		session = runCommand("api", server.URL(), "--ca-cert", "../test/server-tls-ca.pem", "--proxy", "socks6://localhost:1080")
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("invalid proxy: proxy: unknown scheme: socks6"))
+
+		login()
+		cfg := config.ReadConfig()
+		cfg.Proxy = "socks6://localhost:1080"
+		config.WriteConfig(cfg)
+
+		session = runCommand("get", "-n", "/example-password")
 		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("invalid proxy: proxy: unknown scheme: socks6"))
 	})
 })
 
@@ -13,8 +13,7 @@ func init() {
 		cfg := config.ReadConfig()
 
 		if cfg.AccessToken != "" && cfg.AccessToken != "revoked" {
-			cfg = refreshConfiguration(cfg)
-			config.WriteConfig(cfg)
+			cfg = refreshSavedConfiguration(cfg)
 			fmt.Println("Bearer " + cfg.AccessToken)
 		} else if os.Getenv("CREDHUB_CLIENT") != "" && os.Getenv("CREDHUB_SECRET") != "" {
 			cfg = refreshConfiguration(cfg)
@@ -40,3 +39,30 @@ func refreshConfiguration(cfg config.Config) config.Config {
 	cfg.RefreshToken = oauth.RefreshToken()
 	return cfg
 }
+
+// refreshSavedConfiguration refreshes the tokens saved in the config file and
+// saves the new tokens. The config file stays locked from reading the tokens
+// until the new ones are saved, so that commands running at the same time do
+// not refresh with the same refresh token.
+func refreshSavedConfiguration(cfg config.Config) config.Config {
+	credhubClient, _ := initializeCredhubClient(cfg)
+	oauth := credhubClient.Auth.(*auth.OAuthStrategy)
+
+	config.UpdateConfig(func(stored *config.Config) error {
+		if stored.AccessToken != "" && stored.AccessToken != "revoked" {
+			oauth.SetTokens(stored.AccessToken, stored.RefreshToken)
+		}
+
+		if err := oauth.Refresh(); err != nil {
+			fmt.Println("Bearer " + cfg.AccessToken)
+		}
+
+		cfg.AccessToken = oauth.AccessToken()
+		cfg.RefreshToken = oauth.RefreshToken()
+		stored.AccessToken = cfg.AccessToken
+		stored.RefreshToken = cfg.RefreshToken
+		return nil
+	})
+
+	return cfg
+}
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/credhub-cli/credhub"
+	"code.cloudfoundry.org/credhub-cli/errors"
+)
+
+type WatchCommand struct {
+	CredentialIdentifiers []CredentialName `short:"n" long:"name" description:"Name of a credential to watch. May be provided multiple times"`
+	Path                  CredentialPath   `short:"p" long:"path" description:"Watch all credentials within the provided path"`
+	Exec                  string           `long:"exec" description:"Shell command to run after a credential has changed, e.g. 'systemctl reload nginx'"`
+	WriteDir              string           `long:"write-dir" description:"Directory to write the current value of each credential to, using the credential name as the file path"`
+	Interval              time.Duration    `long:"interval" default:"30s" description:"Time between checks for new versions"`
+	ClientCommand
+}
+
+func (c *WatchCommand) Execute([]string) error {
+	if len(c.CredentialIdentifiers) == 0 && c.Path == "" {
+		return errors.NewMissingWatchNamesError()
+	}
+	if c.Interval <= 0 {
+		return errors.NewInvalidWatchIntervalError()
+	}
+
+	names, err := c.names()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return errors.NewNoMatchingCredentialsFoundError()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	fmt.Printf("Watching %d credential(s) every %s.\n", len(names), c.Interval)
+
+	var events <-chan credhub.WatchEvent
+	if c.Path == "" {
+		events = c.client.Watch(ctx, names, c.Interval)
+	} else {
+		// The path is listed again on each poll, so that credentials added
+		// under it are watched too. The first poll uses the names listed above.
+		first := true
+		events = c.client.WatchFunc(ctx, func() ([]string, error) {
+			if first {
+				first = false
+				return names, nil
+			}
+			return c.names()
+		}, c.Interval)
+	}
+
+	for event := range events {
+		c.handle(event)
+	}
+
+	return nil
+}
+
+func (c *WatchCommand) names() ([]string, error) {
+	var names []string
+	for _, name := range c.CredentialIdentifiers {
+		names = append(names, string(name))
+	}
+
+	if c.Path != "" {
+		results, err := c.client.FindByPath(string(c.Path))
+		if err != nil {
+			return nil, err
+		}
+		for _, cred := range results.Credentials {
+			names = append(names, cred.Name)
+		}
+	}
+
+	return names, nil
+}
+
+func (c *WatchCommand) handle(event credhub.WatchEvent) {
+	if event.Err != nil && event.Name == "" {
+		fmt.Fprintf(os.Stderr, "Listing '%s' failed: %s\n", c.Path, event.Err)
+		return
+	}
+	if event.Err != nil {
+		fmt.Fprintf(os.Stderr, "Checking '%s' failed: %s\n", event.Name, event.Err)
+		return
+	}
+
+	if event.Initial {
+		fmt.Printf("Found '%s' at version %s.\n", event.Name, event.Credential.Id)
+	} else {
+		fmt.Printf("Changed '%s' from version %s to %s.\n", event.Name, event.PreviousId, event.Credential.Id)
+	}
+
+	env := []string{
+		"CREDHUB_NAME=" + event.Name,
+		"CREDHUB_VERSION_ID=" + event.Credential.Id,
+		"CREDHUB_PREVIOUS_VERSION_ID=" + event.PreviousId,
+	}
+
+	if c.WriteDir != "" {
+		file, err := writeCredentialFile(c.WriteDir, event.Name, event.Credential.Value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Writing '%s' failed: %s\n", event.Name, err)
+			return
+		}
+		env = append(env, "CREDHUB_FILE="+file)
+	}
+
+	if c.Exec != "" && !event.Initial {
+		cmd := exec.Command("sh", "-c", c.Exec)
+		cmd.Env = append(os.Environ(), env...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Running '%s' for '%s' failed: %s\n", c.Exec, event.Name, err)
+		}
+	}
+}
+
+// writeCredentialFile replaces the file for the credential atomically, so
+// that readers never see a partially written value. Names that would be
+// written outside of dir are rejected.
+func writeCredentialFile(dir, name string, value interface{}) (string, error) {
+	content, err := credhub.RawEscaper(value)
+	if err != nil {
+		return "", err
+	}
+
+	rel := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(name, "/")))
+	if filepath.IsAbs(rel) || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.NewInvalidWatchFileNameError(name)
+	}
+
+	file := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return "", err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), "."+filepath.Base(file)+".tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	return file, os.Rename(tmp.Name(), file)
+}
@@ -0,0 +1,166 @@
+package commands_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Watch", func() {
+	BeforeEach(func() {
+		login()
+	})
+
+	AfterEach(func() {
+		KillAndWait()
+	})
+
+	ItRequiresAuthentication("watch", "-n", "test-credential")
+	ItRequiresAnAPIToBeSet("watch", "-n", "test-credential")
+
+	Describe("Help", func() {
+		ItBehavesLikeHelp("watch", "watch", func(session *Session) {
+			Expect(session.Err).To(Say("watch"))
+			Expect(session.Err).To(Say("exec"))
+		})
+	})
+
+	It("requires a name or path", func() {
+		session := runCommand("watch")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("A name or path must be provided."))
+	})
+
+	It("requires a positive interval", func() {
+		session := runCommand("watch", "-n", "my-password", "--interval", "0s")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The interval must be a positive duration"))
+	})
+
+	Context("when the credential changes", func() {
+		var writeDir string
+
+		BeforeEach(func() {
+			var err error
+			writeDir, err = ioutil.TempDir("", "credhub-watch")
+			Expect(err).NotTo(HaveOccurred())
+
+			respond := func(id, value string) http.HandlerFunc {
+				return CombineHandlers(
+					VerifyRequest("GET", "/api/v1/data", "current=true&name=/deploy/my-password"),
+					RespondWith(http.StatusOK, `{"data":[{"type":"password","id":"`+id+`","name":"/deploy/my-password","version_created_at":"`+TIMESTAMP+`","value":"`+value+`"}]}`),
+				)
+			}
+
+			requests := 0
+			server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests <= 2 {
+					respond("id-1", "first-password")(w, r)
+				} else {
+					respond("id-2", "second-password")(w, r)
+				}
+			})
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(writeDir)
+		})
+
+		It("writes the new value and runs the exec command", func() {
+			session := startCommand("watch", "-n", "/deploy/my-password", "--interval", "50ms",
+				"--write-dir", writeDir,
+				"--exec", `echo "reloading $CREDHUB_NAME $CREDHUB_PREVIOUS_VERSION_ID $CREDHUB_VERSION_ID $(cat $CREDHUB_FILE)"`)
+
+			Eventually(session.Out).Should(Say("Watching 1 credential\\(s\\) every 50ms."))
+			Eventually(session.Out).Should(Say("Found '/deploy/my-password' at version id-1."))
+			Eventually(session.Out).Should(Say("Changed '/deploy/my-password' from version id-1 to id-2."))
+			Eventually(session.Out).Should(Say("reloading /deploy/my-password id-1 id-2 second-password"))
+
+			content, err := ioutil.ReadFile(filepath.Join(writeDir, "deploy", "my-password"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("second-password"))
+
+			info, err := os.Stat(filepath.Join(writeDir, "deploy", "my-password"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+			session.Interrupt()
+			Eventually(session).Should(Exit(0))
+		})
+
+		It("does not write credentials whose names lead outside of the write directory", func() {
+			server.RouteToHandler("GET", "/api/v1/data",
+				RespondWith(http.StatusOK, `{"data":[{"type":"password","id":"id-1","name":"/escaped","version_created_at":"`+TIMESTAMP+`","value":"password"}]}`),
+			)
+
+			session := startCommand("watch", "-n", "/../escaped", "--interval", "50ms", "--write-dir", writeDir)
+
+			Eventually(session.Err).Should(Say(`Writing '/\.\./escaped' failed: The name '/\.\./escaped' cannot be written to a file under the write directory\.`))
+			_, err := os.Stat(filepath.Join(filepath.Dir(writeDir), "escaped"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+
+			session.Interrupt()
+			Eventually(session).Should(Exit(0))
+		})
+	})
+
+	It("watches credentials that are added under the path", func() {
+		finds := 0
+		server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("path") == "" {
+				name := r.URL.Query().Get("name")
+				w.Write([]byte(`{"data":[{"type":"password","id":"id-1","name":"` + name + `","version_created_at":"` + TIMESTAMP + `","value":"some-password"}]}`))
+				return
+			}
+
+			finds++
+			if finds == 1 {
+				w.Write([]byte(`{"credentials":[{"name":"/deploy/first-password","version_created_at":"` + TIMESTAMP + `"}]}`))
+			} else {
+				w.Write([]byte(`{"credentials":[{"name":"/deploy/first-password","version_created_at":"` + TIMESTAMP + `"},{"name":"/deploy/second-password","version_created_at":"` + TIMESTAMP + `"}]}`))
+			}
+		})
+
+		session := startCommand("watch", "-p", "/deploy", "--interval", "50ms")
+
+		Eventually(session.Out).Should(Say("Watching 1 credential\\(s\\) every 50ms."))
+		Eventually(session.Out).Should(Say("Found '/deploy/first-password' at version id-1."))
+		Eventually(session.Out).Should(Say("Found '/deploy/second-password' at version id-1."))
+
+		session.Interrupt()
+		Eventually(session).Should(Exit(0))
+	})
+
+	It("reports failed checks and keeps watching", func() {
+		server.RouteToHandler("GET", "/api/v1/data",
+			RespondWith(http.StatusNotFound, `{"error":"The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`),
+		)
+
+		session := startCommand("watch", "-n", "my-password", "--interval", "50ms")
+
+		Eventually(session.Err).Should(Say("Checking 'my-password' failed: The request could not be completed"))
+		Consistently(session).ShouldNot(Exit())
+
+		session.Interrupt()
+		Eventually(session).Should(Exit(0))
+	})
+})
+
+// startCommand runs the CLI without waiting for it to exit
+func startCommand(args ...string) *Session {
+	session, err := Start(exec.Command(commandPath, args...), GinkgoWriter, GinkgoWriter)
+	Expect(err).NotTo(HaveOccurred())
+
+	return session
+}
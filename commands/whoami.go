@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/credhub-cli/config"
+	"code.cloudfoundry.org/credhub-cli/credhub/auth"
+	"code.cloudfoundry.org/credhub-cli/credhub/auth/uaa"
+	"code.cloudfoundry.org/credhub-cli/errors"
+)
+
+type WhoamiCommand struct {
+	Verify bool `long:"verify" description:"Confirm the identity with the auth server, using its userinfo endpoint for users and check_token endpoint for clients"`
+	OutputCommand
+	ClientCommand
+	ConfigCommand
+}
+
+type whoamiReport struct {
+	UserName  string   `json:"user_name,omitempty" yaml:"user_name,omitempty"`
+	ClientId  string   `json:"client_id" yaml:"client_id"`
+	GrantType string   `json:"grant_type,omitempty" yaml:"grant_type,omitempty"`
+	Scope     []string `json:"scope" yaml:"scope"`
+	Issuer    string   `json:"issuer" yaml:"issuer"`
+	ExpiresAt string   `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	Expired   bool     `json:"expired" yaml:"expired"`
+	ApiURL    string   `json:"api_url" yaml:"api_url"`
+	AuthURL   string   `json:"auth_url" yaml:"auth_url"`
+	Verified  bool     `json:"verified,omitempty" yaml:"verified,omitempty"`
+}
+
+// tokenClaims are the claims of a UAA access token shown by whoami
+type tokenClaims struct {
+	UserName  string      `json:"user_name"`
+	ClientId  string      `json:"client_id"`
+	GrantType string      `json:"grant_type"`
+	Scope     interface{} `json:"scope"`
+	Expiry    int64       `json:"exp"`
+	Issuer    string      `json:"iss"`
+}
+
+func (c *WhoamiCommand) Execute([]string) error {
+	if err := c.validateOutput(); err != nil {
+		return err
+	}
+
+	oauth, ok := c.client.Auth.(*auth.OAuthStrategy)
+	if !ok {
+		return errors.NewRevokedTokenError()
+	}
+	if err := oauth.Login(); err != nil {
+		return err
+	}
+
+	token := oauth.AccessToken()
+	claims, err := decodeTokenClaims(token)
+	if err != nil {
+		return err
+	}
+
+	report := whoamiReport{
+		UserName:  claims.UserName,
+		ClientId:  claims.ClientId,
+		GrantType: claims.GrantType,
+		Scope:     tokenScopes(claims.Scope),
+		Issuer:    claims.Issuer,
+		ApiURL:    c.config.ApiURL,
+		AuthURL:   c.config.AuthURL,
+	}
+	if claims.Expiry != 0 {
+		expiresAt := time.Unix(claims.Expiry, 0).UTC()
+		report.ExpiresAt = expiresAt.Format(time.RFC3339)
+		report.Expired = !time.Now().Before(expiresAt)
+	}
+
+	if c.Verify {
+		if c.config.AuthType == config.AuthTypeOidc {
+			return errors.NewUnsupportedWithOidcError("--verify")
+		}
+		if err := c.verifyClaims(token, claims); err != nil {
+			return err
+		}
+		report.Verified = true
+	}
+
+	return c.printOutput(report)
+}
+
+// verifyClaims confirms the user or client of the token with the auth server
+func (c *WhoamiCommand) verifyClaims(token string, claims tokenClaims) error {
+	uaaClient := uaa.Client{
+		AuthURL: c.config.AuthURL,
+		Client:  c.client.Client(),
+	}
+
+	var (
+		confirmed map[string]interface{}
+		err       error
+	)
+	claim, value := "user_name", claims.UserName
+	if claims.UserName != "" {
+		confirmed, err = uaaClient.UserInfo(token)
+	} else {
+		claim, value = "client_id", claims.ClientId
+		clientId, clientSecret, _ := authClient(c.config)
+		confirmed, err = uaaClient.CheckToken(clientId, clientSecret, token)
+	}
+	if err != nil {
+		return errors.NewTokenVerificationError(err)
+	}
+
+	if confirmed[claim] != value {
+		return errors.NewTokenClaimMismatchError(claim)
+	}
+
+	return nil
+}
+
+// decodeTokenClaims decodes the payload of a JWT access token without
+// verifying its signature
+func decodeTokenClaims(token string) (tokenClaims, error) {
+	var claims tokenClaims
+
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return claims, errors.NewInvalidAccessTokenError()
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segments[1], "="))
+	if err != nil {
+		return claims, errors.NewInvalidAccessTokenError()
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, errors.NewInvalidAccessTokenError()
+	}
+
+	return claims, nil
+}
+
+// tokenScopes returns the scope claim, which UAA encodes as a list and other
+// servers as a space-separated string
+func tokenScopes(scope interface{}) []string {
+	scopes := []string{}
+	switch typed := scope.(type) {
+	case string:
+		scopes = append(scopes, strings.Fields(typed)...)
+	case []interface{}:
+		for _, s := range typed {
+			if s, ok := s.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,144 @@
+package commands_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/credhub-cli/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Whoami", func() {
+	setAccessToken := func(claims string) {
+		cfg := config.ReadConfig()
+		cfg.AccessToken = "e30." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".e30"
+		cfg.RefreshToken = "some-refresh-token"
+		cfg.AuthURL = authServer.URL()
+		Expect(config.WriteConfig(cfg)).To(Succeed())
+	}
+
+	userClaims := `{"user_name":"some-user","client_id":"credhub_cli","grant_type":"password","scope":["credhub.read","credhub.write"],"exp":4102444800,"iss":"https://uaa.example.com/oauth/token"}`
+
+	ItRequiresAuthentication("whoami")
+	ItRequiresAnAPIToBeSet("whoami")
+
+	Describe("Help", func() {
+		ItBehavesLikeHelp("whoami", "whoami", func(session *Session) {
+			Expect(session.Err).To(Say("whoami"))
+			Expect(session.Err).To(Say("verify"))
+		})
+	})
+
+	It("shows the claims of the access token with the API and auth server", func() {
+		setAccessToken(userClaims)
+
+		session := runCommand("whoami", "--output", "json")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out.Contents()).To(MatchJSON(fmt.Sprintf(`{
+			"user_name": "some-user",
+			"client_id": "credhub_cli",
+			"grant_type": "password",
+			"scope": ["credhub.read", "credhub.write"],
+			"issuer": "https://uaa.example.com/oauth/token",
+			"expires_at": "2100-01-01T00:00:00Z",
+			"expired": false,
+			"api_url": "%s",
+			"auth_url": "%s"
+		}`, server.URL(), authServer.URL())))
+	})
+
+	It("shows when the access token has expired", func() {
+		setAccessToken(`{"client_id":"some-client","scope":"credhub.read","exp":1504907985}`)
+
+		session := runCommand("whoami")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("client_id: some-client"))
+		Expect(session.Out).To(Say("- credhub.read"))
+		Expect(session.Out).To(Say("expires_at: \"2017-09-08T21:59:45Z\""))
+		Expect(session.Out).To(Say("expired: true"))
+	})
+
+	It("fails when the access token cannot be decoded", func() {
+		cfg := config.ReadConfig()
+		cfg.AccessToken = "not-a-jwt"
+		Expect(config.WriteConfig(cfg)).To(Succeed())
+
+		session := runCommand("whoami")
+
+		Eventually(session).Should(Exit(3))
+		Expect(session.Err).To(Say("The access token could not be decoded. Please log in to continue."))
+	})
+
+	Context("with --verify", func() {
+		It("confirms the user with the userinfo endpoint", func() {
+			setAccessToken(userClaims)
+			authServer.RouteToHandler("GET", "/userinfo", RespondWith(http.StatusOK, `{"user_name":"some-user","user_id":"1234"}`))
+
+			session := runCommand("whoami", "--verify", "-j")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say(`"verified": true`))
+		})
+
+		It("confirms the client with the check_token endpoint", func() {
+			setAccessToken(`{"client_id":"test_client","grant_type":"client_credentials","scope":["credhub.read"],"exp":4102444800}`)
+			authServer.RouteToHandler("POST", "/check_token", CombineHandlers(
+				VerifyBasicAuth("test_client", "test_secret"),
+				VerifyFormKV("token", "e30."+base64.RawURLEncoding.EncodeToString([]byte(`{"client_id":"test_client","grant_type":"client_credentials","scope":["credhub.read"],"exp":4102444800}`))+".e30"),
+				RespondWith(http.StatusOK, `{"client_id":"test_client"}`),
+			))
+
+			session := runCommandWithEnv([]string{"CREDHUB_CLIENT=test_client", "CREDHUB_SECRET=test_secret"}, "whoami", "--verify")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("client_id: test_client"))
+			Expect(session.Out).To(Say("verified: true"))
+		})
+
+		It("confirms the client with the client saved in the config when CREDHUB_CLIENT is not set", func() {
+			claims := `{"client_id":"saved_client","grant_type":"client_credentials","scope":["credhub.read"],"exp":4102444800}`
+			setAccessToken(claims)
+			cfg := config.ReadConfig()
+			cfg.ClientID = "saved_client"
+			cfg.ClientSecret = "saved_secret"
+			Expect(config.WriteConfig(cfg)).To(Succeed())
+			authServer.RouteToHandler("POST", "/check_token", CombineHandlers(
+				VerifyBasicAuth("saved_client", "saved_secret"),
+				RespondWith(http.StatusOK, `{"client_id":"saved_client"}`),
+			))
+
+			session := runCommand("whoami", "--verify")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("verified: true"))
+		})
+
+		It("fails when the auth server rejects the token", func() {
+			setAccessToken(userClaims)
+			authServer.RouteToHandler("GET", "/userinfo", RespondWith(http.StatusUnauthorized, `{"error":"invalid_token","error_description":"Token has expired"}`))
+
+			session := runCommand("whoami", "--verify")
+
+			Eventually(session).Should(Exit(3))
+			Expect(session.Err).To(Say("The access token could not be verified by the auth server: invalid_token Token has expired. Please log in to continue."))
+		})
+
+		It("fails when the auth server returns another user", func() {
+			setAccessToken(userClaims)
+			authServer.RouteToHandler("GET", "/userinfo", RespondWith(http.StatusOK, `{"user_name":"another-user"}`))
+
+			session := runCommand("whoami", "--verify")
+
+			Eventually(session).Should(Exit(3))
+			Expect(session.Err).To(Say("The user_name of the access token does not match the auth server. Please log in to continue."))
+		})
+	})
+})
@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path"
+	"path/filepath"
+	"reflect"
 
 	"code.cloudfoundry.org/credhub-cli/util"
 )
@@ -13,6 +14,13 @@ import (
 const AuthClient = "credhub_cli"
 const AuthPassword = ""
 
+// AuthTypeUaa and AuthTypeOidc are the types of auth servers. An empty
+// AuthType is a UAA.
+const (
+	AuthTypeUaa  = "uaa"
+	AuthTypeOidc = "oidc"
+)
+
 type Config struct {
 	ApiURL             string
 	AuthURL            string
@@ -23,27 +31,40 @@ type Config struct {
 	ServerVersion      string
 	ClientID           string
 	ClientSecret       string
+	Proxy              string
+	AuthType           string
 }
 
+// ConfigDir returns the directory of the config file and other files of the
+// CLI. It is CREDHUB_HOME when set. Otherwise it is ~/.credhub, unless that
+// does not exist and XDG_CONFIG_HOME is set, in which case it is
+// $XDG_CONFIG_HOME/credhub.
 func ConfigDir() string {
-	return path.Join(userHomeDir(), ".credhub")
+	if home := os.Getenv("CREDHUB_HOME"); home != "" {
+		return home
+	}
+
+	dir := filepath.Join(userHomeDir(), ".credhub")
+	if xdgHome := os.Getenv("XDG_CONFIG_HOME"); xdgHome != "" {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			return filepath.Join(xdgHome, "credhub")
+		}
+	}
+
+	return dir
 }
 
+// ConfigPath returns the path of the config file, which is CREDHUB_CONFIG when
+// set, e.g. to isolate parallel jobs, or config.json in ConfigDir otherwise
 func ConfigPath() string {
-	return path.Join(ConfigDir(), "config.json")
+	if configPath := os.Getenv("CREDHUB_CONFIG"); configPath != "" {
+		return configPath
+	}
+	return filepath.Join(ConfigDir(), "config.json")
 }
 
 func ReadConfig() Config {
-	c := Config{}
-
-	data, err := ioutil.ReadFile(ConfigPath())
-	if err != nil {
-		if !os.IsNotExist(err) {
-			return c
-		}
-	}
-
-	json.Unmarshal(data, &c)
+	c := readConfigFile(ConfigPath())
 
 	if server, ok := os.LookupEnv("CREDHUB_SERVER"); ok {
 		c.ApiURL = util.AddDefaultSchemeIfNecessary(server)
@@ -69,19 +90,143 @@ func ReadConfig() Config {
 	return c
 }
 
+// WriteConfig replaces the config file with c. The file is replaced by
+// renaming a new file over it, while holding a lock on the config, so that
+// concurrent readers and writers never see a partially written file.
 func WriteConfig(c Config) error {
-	err := makeDirectory()
+	configPath := ConfigPath()
+
+	err := makeDirectory(filepath.Dir(configPath))
 	if err != nil {
 		return err
 	}
 
-	data, err := json.Marshal(c)
+	unlock, err := lockConfig(configPath)
 	if err != nil {
 		return err
 	}
+	defer unlock()
 
+	return writeConfigFile(configPath, c)
+}
+
+// UpdateConfig reads the config file, calls update with it and writes the
+// result, while holding the lock on the config for the whole time, so that
+// commands that update the config at the same time do not undo each other's
+// changes. The config is read without the overrides from the environment, and
+// is only written when update changed it and did not return an error.
+func UpdateConfig(update func(*Config) error) error {
 	configPath := ConfigPath()
-	return ioutil.WriteFile(configPath, data, 0600)
+
+	err := makeDirectory(filepath.Dir(configPath))
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockConfig(configPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	original := readConfigFile(configPath)
+	c := original
+
+	if err := update(&c); err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(original, c) {
+		return nil
+	}
+
+	return writeConfigFile(configPath, c)
+}
+
+// WithConfigLock calls f while holding the lock on the config, e.g. to update
+// other files in ConfigDir. f must not write the config itself.
+func WithConfigLock(f func() error) error {
+	configPath := ConfigPath()
+
+	err := makeDirectory(filepath.Dir(configPath))
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockConfig(configPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return f()
+}
+
+// lockConfig waits for an exclusive lock on the config file and returns a
+// function that releases it. The lock is held on a separate file, as the
+// config file itself is replaced while the lock is held. The lock is not
+// reentrant, so it must not be taken again before it is released.
+func lockConfig(configPath string) (func(), error) {
+	file, err := os.OpenFile(configPath+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return func() {
+		unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+func readConfigFile(configPath string) Config {
+	c := Config{}
+
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return c
+	}
+
+	json.Unmarshal(data, &c)
+	return c
+}
+
+func writeConfigFile(configPath string, c Config) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(configPath, data)
+}
+
+func writeFileAtomically(filename string, data []byte) error {
+	tempFile, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(data)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tempFile.Name(), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), filename)
 }
 
 func RemoveConfig() error {
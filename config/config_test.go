@@ -3,7 +3,13 @@
 package config_test
 
 import (
+	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/credhub-cli/config"
 	. "github.com/onsi/ginkgo"
@@ -24,6 +30,112 @@ var _ = Describe("Config", func() {
 		Expect(config.ConfigPath()).To(HaveSuffix(`/.credhub/config.json`))
 	})
 
+	Describe("the config location", func() {
+		var homeDir, originalHome string
+
+		BeforeEach(func() {
+			var err error
+			homeDir, err = ioutil.TempDir("", "credhub-home")
+			Expect(err).NotTo(HaveOccurred())
+
+			originalHome = os.Getenv("HOME")
+			os.Setenv("HOME", homeDir)
+		})
+
+		AfterEach(func() {
+			os.Setenv("HOME", originalHome)
+			os.Unsetenv("CREDHUB_CONFIG")
+			os.Unsetenv("CREDHUB_HOME")
+			os.Unsetenv("XDG_CONFIG_HOME")
+			os.RemoveAll(homeDir)
+		})
+
+		It("uses CREDHUB_CONFIG as the config file", func() {
+			os.Setenv("CREDHUB_CONFIG", filepath.Join(homeDir, "job-1", "credhub.json"))
+
+			Expect(config.WriteConfig(cfg)).To(Succeed())
+
+			Expect(filepath.Join(homeDir, "job-1", "credhub.json")).To(BeARegularFile())
+			Expect(config.ReadConfig().ApiURL).To(Equal("http://api.example.com"))
+		})
+
+		It("uses CREDHUB_HOME as the config directory", func() {
+			os.Setenv("CREDHUB_HOME", filepath.Join(homeDir, "credhub-home"))
+
+			Expect(config.ConfigDir()).To(Equal(filepath.Join(homeDir, "credhub-home")))
+			Expect(config.ConfigPath()).To(Equal(filepath.Join(homeDir, "credhub-home", "config.json")))
+		})
+
+		It("uses XDG_CONFIG_HOME unless ~/.credhub exists", func() {
+			os.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+			Expect(config.ConfigDir()).To(Equal(filepath.Join(homeDir, ".config", "credhub")))
+
+			Expect(os.Mkdir(filepath.Join(homeDir, ".credhub"), 0755)).To(Succeed())
+			Expect(config.ConfigDir()).To(Equal(filepath.Join(homeDir, ".credhub")))
+		})
+
+		It("never exposes a partially written config to concurrent writers and readers", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(2)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					Expect(config.WriteConfig(config.Config{AccessToken: strings.Repeat(string(rune('a'+i)), 100000)})).To(Succeed())
+				}(i)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					data, err := ioutil.ReadFile(config.ConfigPath())
+					if err == nil {
+						Expect(json.Valid(data)).To(BeTrue())
+					}
+				}()
+			}
+			wg.Wait()
+
+			Expect(config.ReadConfig().AccessToken).To(HaveLen(100000))
+
+			files, err := ioutil.ReadDir(config.ConfigDir())
+			Expect(err).NotTo(HaveOccurred())
+			var names []string
+			for _, f := range files {
+				names = append(names, f.Name())
+			}
+			Expect(names).To(ConsistOf("config.json", "config.json.lock"))
+		})
+
+		It("does not lose the changes of concurrent updates", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					Expect(config.UpdateConfig(func(c *config.Config) error {
+						c.AccessToken += "a"
+						return nil
+					})).To(Succeed())
+				}()
+			}
+			wg.Wait()
+
+			Expect(config.ReadConfig().AccessToken).To(Equal(strings.Repeat("a", 20)))
+		})
+
+		It("does not write the config when the update fails", func() {
+			Expect(config.WriteConfig(config.Config{AccessToken: "token"})).To(Succeed())
+
+			err := config.UpdateConfig(func(c *config.Config) error {
+				c.AccessToken = "other-token"
+				return errors.New("failed")
+			})
+
+			Expect(err).To(MatchError("failed"))
+			Expect(config.ReadConfig().AccessToken).To(Equal("token"))
+		})
+	})
+
 	Describe("#UpdateTrustedCAs", func() {
 		It("reads multiple certs", func() {
 			ca1, err := ioutil.ReadFile("../test/server-tls-ca.pem")
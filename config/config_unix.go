@@ -4,12 +4,21 @@ package config
 
 import (
 	"os"
+	"syscall"
 )
 
 func userHomeDir() string {
 	return os.Getenv("HOME")
 }
 
-func makeDirectory() error {
-	return os.MkdirAll(ConfigDir(), 0755)
+func makeDirectory(dir string) error {
+	return os.MkdirAll(dir, 0755)
+}
+
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
 }
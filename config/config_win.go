@@ -4,7 +4,18 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"unsafe"
+)
+
+const lockfileExclusiveLock = 0x2
+
+var (
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = kernel32.NewProc("LockFileEx")
+	procUnlockFileEx = kernel32.NewProc("UnlockFileEx")
 )
 
 func userHomeDir() string {
@@ -15,14 +26,17 @@ func userHomeDir() string {
 	return home
 }
 
-func makeDirectory() error {
-	dir := ConfigDir()
-
+// makeDirectory creates dir, which is hidden when its name starts with a dot
+func makeDirectory(dir string) error {
 	err := os.MkdirAll(dir, 0755)
 	if err != nil {
 		return err
 	}
 
+	if !strings.HasPrefix(filepath.Base(dir), ".") {
+		return nil
+	}
+
 	p, err := syscall.UTF16PtrFromString(dir)
 	if err != nil {
 		return err
@@ -35,3 +49,21 @@ func makeDirectory() error {
 
 	return syscall.SetFileAttributes(p, attrs|syscall.FILE_ATTRIBUTE_HIDDEN)
 }
+
+func lockFile(f *os.File) error {
+	var overlapped syscall.Overlapped
+	r, _, err := procLockFileEx.Call(f.Fd(), lockfileExclusiveLock, 0, 1, 0, uintptr(unsafe.Pointer(&overlapped)))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	var overlapped syscall.Overlapped
+	r, _, err := procUnlockFileEx.Call(f.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(&overlapped)))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
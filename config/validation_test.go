@@ -30,14 +30,14 @@ var _ = Describe("Config validation", func() {
 		cfg.ApiURL = "http://api.example.com"
 		cfg.AccessToken = "revoked"
 
-		Expect(config.ValidateConfig(cfg)).To(Equal(errors.New("You are not currently authenticated. Please log in to continue.")))
+		Expect(config.ValidateConfig(cfg)).To(MatchError("You are not currently authenticated. Please log in to continue."))
 	})
 
 	It("requires a non-empty token", func() {
 		cfg := config.Config{}
 		cfg.ApiURL = "http://api.example.com"
 
-		Expect(config.ValidateConfig(cfg)).To(Equal(errors.New("You are not currently authenticated. Please log in to continue.")))
+		Expect(config.ValidateConfig(cfg)).To(MatchError("You are not currently authenticated. Please log in to continue."))
 
 	})
 })
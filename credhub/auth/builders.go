@@ -3,6 +3,7 @@ package auth
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/credhub-cli/credhub/auth/oidc"
 	"code.cloudfoundry.org/credhub-cli/credhub/auth/uaa"
 )
 
@@ -49,18 +50,57 @@ func Uaa(clientId, clientSecret, username, password, accessToken, refreshToken s
 			Client:  httpClient,
 		}
 
-		oauth := &OAuthStrategy{
-			Username:                username,
-			Password:                password,
-			ClientId:                clientId,
-			ClientSecret:            clientSecret,
-			ApiClient:               httpClient,
-			OAuthClient:             &uaaClient,
-			ClientCredentialRefresh: usingClientCrendentials,
+		return newOAuthStrategy(&uaaClient, httpClient, clientId, clientSecret, username, password, accessToken, refreshToken, usingClientCrendentials), nil
+	}
+}
+
+// OidcPassword builds an OauthStrategy for an OpenID Connect provider using password_grant token requests
+func OidcPassword(clientId, clientSecret, username, password string) Builder {
+	return Oidc(clientId, clientSecret, username, password, "", "", false)
+}
+
+// OidcClientCredentials builds an OauthStrategy for an OpenID Connect provider using client_credential_grant token requests
+func OidcClientCredentials(clientId, clientSecret string) Builder {
+	return Oidc(clientId, clientSecret, "", "", "", "", true)
+}
+
+// Oidc builds an OauthStrategy for an OpenID Connect provider using existing tokens
+//
+// The auth server URL of the CredHub server is the issuer of the provider. The
+// token and revocation endpoints are read from the discovery document of the
+// issuer when they are first needed.
+func Oidc(clientId, clientSecret, username, password, accessToken, refreshToken string, usingClientCredentials bool) Builder {
+	return func(config Config) (Strategy, error) {
+		httpClient := config.Client()
+		authUrl, err := config.AuthURL()
+
+		if err != nil {
+			return nil, err
+		}
+
+		oidcClient := oidc.Client{
+			Issuer:       authUrl,
+			ClientId:     clientId,
+			ClientSecret: clientSecret,
+			Client:       httpClient,
 		}
 
-		oauth.SetTokens(accessToken, refreshToken)
+		return newOAuthStrategy(&oidcClient, httpClient, clientId, clientSecret, username, password, accessToken, refreshToken, usingClientCredentials), nil
+	}
+}
 
-		return oauth, nil
+func newOAuthStrategy(oauthClient OAuthClient, httpClient *http.Client, clientId, clientSecret, username, password, accessToken, refreshToken string, usingClientCredentials bool) *OAuthStrategy {
+	oauth := &OAuthStrategy{
+		Username:                username,
+		Password:                password,
+		ClientId:                clientId,
+		ClientSecret:            clientSecret,
+		ApiClient:               httpClient,
+		OAuthClient:             oauthClient,
+		ClientCredentialRefresh: usingClientCredentials,
 	}
+
+	oauth.SetTokens(accessToken, refreshToken)
+
+	return oauth
 }
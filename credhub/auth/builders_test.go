@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"code.cloudfoundry.org/credhub-cli/credhub/auth/oidc"
 	"code.cloudfoundry.org/credhub-cli/credhub/auth/uaa"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -124,4 +125,55 @@ var _ = Describe("Constructors", func() {
 			})
 		})
 	})
+
+	Describe("Oidc()", func() {
+		It("constructs a OAuthStrategy auth for an OpenID Connect provider using existing tokens", func() {
+			config := DummyServerConfig{}
+			builder := Oidc("some-client-id",
+				"some-client-secret",
+				"some-username",
+				"some-password",
+				"some-access-token",
+				"some-refresh-token",
+				false)
+			strategy, _ := builder(&config)
+			auth := strategy.(*OAuthStrategy)
+			Expect(auth.ClientId).To(Equal("some-client-id"))
+			Expect(auth.ClientSecret).To(Equal("some-client-secret"))
+			Expect(auth.Username).To(Equal("some-username"))
+			Expect(auth.Password).To(Equal("some-password"))
+			Expect(auth.AccessToken()).To(Equal("some-access-token"))
+			Expect(auth.RefreshToken()).To(Equal("some-refresh-token"))
+			Expect(auth.ClientCredentialRefresh).To(BeFalse())
+			oidcClient := auth.OAuthClient.(*oidc.Client)
+			Expect(oidcClient.Issuer).To(Equal("http://example.com/auth/url"))
+			Expect(oidcClient.ClientId).To(Equal("some-client-id"))
+			Expect(oidcClient.ClientSecret).To(Equal("some-client-secret"))
+			client := config.Client()
+			Expect(oidcClient.Client).To(BeIdenticalTo(client))
+			Expect(auth.ApiClient).To(BeIdenticalTo(client))
+		})
+
+		It("constructs a OAuthStrategy auth using client credentials grant", func() {
+			config := DummyServerConfig{}
+			builder := OidcClientCredentials("some-client-id", "some-client-secret")
+			strategy, _ := builder(&config)
+			auth := strategy.(*OAuthStrategy)
+			Expect(auth.ClientCredentialRefresh).To(BeTrue())
+			Expect(auth.Username).To(BeEmpty())
+			Expect(auth.OAuthClient.(*oidc.Client).Issuer).To(Equal("http://example.com/auth/url"))
+		})
+
+		Context("when fetching an Auth URL fails", func() {
+			It("returns an error", func() {
+				config := DummyServerConfig{
+					Error: errors.New("Failed to fetch Auth URL"),
+				}
+				builder := OidcPassword("some-client-id", "some-client-secret", "some-username", "some-password")
+				_, err := builder(&config)
+
+				Expect(err).To(MatchError("Failed to fetch Auth URL"))
+			})
+		})
+	})
 })
@@ -0,0 +1,15 @@
+package auth
+
+// Error is returned when an access token cannot be obtained or refreshed,
+// e.g. because the refresh token has expired or the client credentials are invalid
+type Error struct {
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -15,7 +15,8 @@ type OAuthStrategy struct {
 	accessToken  string
 	refreshToken string
 
-	mu sync.RWMutex // guards AccessToken & Refresh Token
+	mu        sync.RWMutex // guards AccessToken & Refresh Token
+	refreshMu sync.Mutex   // serializes token requests of concurrent requests
 
 	Username                string
 	Password                string
@@ -36,12 +37,15 @@ type OAuthClient interface {
 // Do submits requests with bearer token authorization, using the AccessToken as the bearer token.
 //
 // Will automatically refresh the AccessToken and retry the request if the token has expired.
+// Concurrent requests that find the same token expired refresh it only once.
+// Failures to obtain or refresh the AccessToken are returned as *Error.
 func (a *OAuthStrategy) Do(req *http.Request) (*http.Response, error) {
 	if err := a.Login(); err != nil {
-		return nil, err
+		return nil, &Error{Err: err}
 	}
 
-	req.Header.Set("Authorization", "Bearer "+a.AccessToken())
+	accessToken := a.AccessToken()
+	req.Header.Set("Authorization", "Bearer "+accessToken)
 
 	clone, err := cloneRequest(req)
 
@@ -49,7 +53,6 @@ func (a *OAuthStrategy) Do(req *http.Request) (*http.Response, error) {
 		return nil, errors.New("failed to clone request body: " + err.Error())
 	}
 
-	req.Header.Set("Authorization", "Bearer "+a.AccessToken())
 	resp, err := a.ApiClient.Do(req)
 
 	if err != nil {
@@ -62,8 +65,8 @@ func (a *OAuthStrategy) Do(req *http.Request) (*http.Response, error) {
 		return resp, err
 	}
 
-	if err := a.Refresh(); err != nil {
-		return nil, err
+	if err := a.refreshExpired(accessToken); err != nil {
+		return nil, &Error{Err: err}
 	}
 
 	req.Header.Set("Authorization", "Bearer "+a.AccessToken())
@@ -102,6 +105,18 @@ func (a *OAuthStrategy) Refresh() error {
 	return nil
 }
 
+// refreshExpired refreshes the expired AccessToken, unless a concurrent
+// request already replaced it while this one waited
+func (a *OAuthStrategy) refreshExpired(expired string) error {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	if a.AccessToken() != expired {
+		return nil
+	}
+	return a.Refresh()
+}
+
 // Logout will send a revoke token request
 //
 // On success, the AccessToken and RefreshToken will be empty
@@ -130,6 +145,9 @@ func (a *OAuthStrategy) Logout() error {
 //
 // Login will be a no-op if the AccessToken is not empty when invoked.
 func (a *OAuthStrategy) Login() error {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
 	if a.AccessToken() != "" && a.AccessToken() != "revoked" {
 		return nil
 	}
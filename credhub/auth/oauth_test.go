@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"code.cloudfoundry.org/credhub-cli/credhub/auth"
 
@@ -198,6 +200,54 @@ var _ = Describe("OAuthStrategy", func() {
 				Expect(string(body)).To(Equal("Success!"))
 			})
 
+			It("refreshes the token only once for concurrent requests", func() {
+				const requests = 5
+				var expired sync.WaitGroup
+				expired.Add(requests)
+
+				apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("Authorization") != "Bearer new-access-token" {
+						expired.Done()
+						expired.Wait()
+						w.WriteHeader(573)
+						w.Write([]byte(`{"error": "access_token_expired"}`))
+					} else {
+						w.Write([]byte(`Success!`))
+					}
+				}))
+
+				defer apiServer.Close()
+
+				oauthClient := &countingOAuthClient{}
+
+				uaa := auth.OAuthStrategy{
+					ClientId:     "client-id",
+					ClientSecret: "client-secret",
+					ApiClient:    http.DefaultClient,
+					OAuthClient:  oauthClient,
+				}
+
+				uaa.SetTokens("old-access-token", "old-refresh-token")
+
+				var done sync.WaitGroup
+				for i := 0; i < requests; i++ {
+					done.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer done.Done()
+
+						request, _ := http.NewRequest("GET", apiServer.URL, nil)
+						response, err := uaa.Do(request)
+
+						Expect(err).ToNot(HaveOccurred())
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					}()
+				}
+				done.Wait()
+
+				Expect(atomic.LoadInt32(&oauthClient.refreshes)).To(Equal(int32(1)))
+			})
+
 			Context("when refreshing token fails", func() {
 				It("returns an error", func() {
 					mockUaaClient.Error = errors.New("failed to refresh")
@@ -218,6 +268,7 @@ var _ = Describe("OAuthStrategy", func() {
 					_, err := oauth.Do(request)
 
 					Expect(err).To(MatchError("failed to refresh"))
+					Expect(err).To(BeAssignableToTypeOf(&auth.Error{}))
 				})
 			})
 
@@ -573,3 +624,13 @@ type errorReader struct {
 func (r *errorReader) Read(b []byte) (n int, err error) {
 	return 0, errors.New("error reading")
 }
+
+type countingOAuthClient struct {
+	dummyUaaClient
+	refreshes int32
+}
+
+func (c *countingOAuthClient) RefreshTokenGrant(clientId, clientSecret, refreshToken string) (string, string, error) {
+	atomic.AddInt32(&c.refreshes, 1)
+	return "new-access-token", "new-refresh-token", nil
+}
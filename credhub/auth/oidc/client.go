@@ -0,0 +1,249 @@
+// OpenID Connect client for token grants and revocation
+package oidc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ErrRevocationNotSupported is returned by RevokeToken when the provider does
+// not advertise a revocation endpoint
+var ErrRevocationNotSupported = errors.New("the OpenID Connect provider does not support token revocation")
+
+// Client makes requests to the OpenID Connect provider at Issuer. The
+// endpoints are read from the discovery document of the issuer when first
+// needed.
+//
+// ClientId and ClientSecret authenticate revocation requests. Token requests
+// are authenticated with the client credentials passed to each grant.
+type Client struct {
+	Issuer       string
+	ClientId     string
+	ClientSecret string
+	Client       *http.Client
+
+	mu       sync.Mutex
+	metadata *Metadata
+}
+
+// Metadata captures the data returned by GET /.well-known/openid-configuration
+// on an OpenID Connect provider. The fields are not exhaustive.
+// See: https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type Metadata struct {
+	Issuer                      string `json:"issuer"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	RevocationEndpoint          string `json:"revocation_endpoint"`
+	UserinfoEndpoint            string `json:"userinfo_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+type token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+}
+
+type responseError struct {
+	Name        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *responseError) Error() string {
+	if e.Description == "" {
+		return e.Name
+	}
+
+	return fmt.Sprintf("%s %s", e.Name, e.Description)
+}
+
+// Metadata returns the discovery document of the issuer. It is requested once
+// and cached by the client.
+func (c *Client) Metadata() (*Metadata, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.metadata != nil {
+		return c.metadata, nil
+	}
+
+	issuer := strings.TrimRight(c.Issuer, "/")
+
+	request, err := http.NewRequest("GET", issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/json")
+
+	response, err := c.Client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	defer io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch the OpenID Connect discovery document of '%s': received HTTP %d", issuer, response.StatusCode)
+	}
+
+	var md Metadata
+	if err := json.NewDecoder(response.Body).Decode(&md); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimRight(md.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("the issuer '%s' of the OpenID Connect discovery document does not match '%s'", md.Issuer, issuer)
+	}
+	if md.TokenEndpoint == "" {
+		return nil, errors.New("the OpenID Connect discovery document does not include a token endpoint")
+	}
+
+	c.metadata = &md
+
+	return c.metadata, nil
+}
+
+// ClientCredentialGrant requests a token using client_credentials grant type
+func (c *Client) ClientCredentialGrant(clientId, clientSecret string) (string, error) {
+	values := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+	}
+
+	token, err := c.tokenGrantRequest(values)
+
+	return token.AccessToken, err
+}
+
+// PasswordGrant requests an access token and refresh token using password grant type
+func (c *Client) PasswordGrant(clientId, clientSecret, username, password string) (string, string, error) {
+	values := url.Values{
+		"grant_type":    {"password"},
+		"username":      {username},
+		"password":      {password},
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+	}
+
+	token, err := c.tokenGrantRequest(values)
+
+	return token.AccessToken, token.RefreshToken, err
+}
+
+// RefreshTokenGrant requests a new access token and refresh token using refresh_token grant type.
+// Providers that do not rotate refresh tokens return none, in which case the given refresh token
+// is returned.
+func (c *Client) RefreshTokenGrant(clientId, clientSecret, refreshToken string) (string, string, error) {
+	values := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+		"refresh_token": {refreshToken},
+	}
+
+	token, err := c.tokenGrantRequest(values)
+	if err == nil && token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+
+	return token.AccessToken, token.RefreshToken, err
+}
+
+// RevokeToken revokes the given access token at the revocation endpoint of the
+// provider, see RFC 7009
+func (c *Client) RevokeToken(accessToken string) error {
+	return c.revoke(accessToken, "access_token")
+}
+
+// RevokeRefreshToken revokes the given refresh token at the revocation
+// endpoint of the provider, see RFC 7009
+func (c *Client) RevokeRefreshToken(refreshToken string) error {
+	return c.revoke(refreshToken, "refresh_token")
+}
+
+func (c *Client) revoke(token, tokenTypeHint string) error {
+	md, err := c.Metadata()
+	if err != nil {
+		return err
+	}
+
+	if md.RevocationEndpoint == "" {
+		return ErrRevocationNotSupported
+	}
+
+	values := url.Values{
+		"token":           {token},
+		"token_type_hint": {tokenTypeHint},
+		"client_id":       {c.ClientId},
+		"client_secret":   {c.ClientSecret},
+	}
+
+	request, err := http.NewRequest("POST", md.RevocationEndpoint, bytes.NewBufferString(values.Encode()))
+	if err != nil {
+		return err
+	}
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := c.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("Received HTTP %d error while revoking token from auth server: %q", response.StatusCode, body)
+	}
+
+	return nil
+}
+
+func (c *Client) tokenGrantRequest(values url.Values) (token, error) {
+	var t token
+
+	md, err := c.Metadata()
+	if err != nil {
+		return t, err
+	}
+
+	request, err := http.NewRequest("POST", md.TokenEndpoint, bytes.NewBufferString(values.Encode()))
+	if err != nil {
+		return t, err
+	}
+	request.Header.Add("Accept", "application/json")
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := c.Client.Do(request)
+	if err != nil {
+		return t, err
+	}
+
+	defer response.Body.Close()
+	defer io.Copy(ioutil.Discard, response.Body)
+
+	decoder := json.NewDecoder(response.Body)
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		err = decoder.Decode(&t)
+		return t, err
+	}
+
+	respErr := responseError{}
+
+	if err := decoder.Decode(&respErr); err != nil {
+		return t, err
+	}
+
+	return t, &respErr
+}
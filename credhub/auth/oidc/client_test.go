@@ -0,0 +1,225 @@
+package oidc_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "code.cloudfoundry.org/credhub-cli/credhub/auth/oidc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		provider       *httptest.Server
+		mux            *http.ServeMux
+		discovery      string
+		discoveryCount int
+		client         *Client
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		provider = httptest.NewServer(mux)
+		discoveryCount = 0
+		discovery = `{
+			"issuer": "%[1]s",
+			"token_endpoint": "%[1]s/token",
+			"revocation_endpoint": "%[1]s/revoke",
+			"userinfo_endpoint": "%[1]s/userinfo"
+		}`
+
+		mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+			discoveryCount++
+			fmt.Fprintf(w, discovery, provider.URL)
+		})
+
+		client = &Client{
+			Issuer:       provider.URL,
+			ClientId:     "client-id",
+			ClientSecret: "client-secret",
+			Client:       http.DefaultClient,
+		}
+	})
+
+	AfterEach(func() {
+		provider.Close()
+	})
+
+	Context("Metadata()", func() {
+		It("reads the endpoints from the discovery document once", func() {
+			md, err := client.Metadata()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(md.TokenEndpoint).To(Equal(provider.URL + "/token"))
+			Expect(md.RevocationEndpoint).To(Equal(provider.URL + "/revoke"))
+
+			_, err = client.Metadata()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(discoveryCount).To(Equal(1))
+		})
+
+		It("returns an error when the issuer does not match", func() {
+			discovery = `{"issuer":"https://other.example.com","token_endpoint":"%s/token"}`
+
+			_, err := client.Metadata()
+
+			Expect(err).To(MatchError(ContainSubstring("the issuer 'https://other.example.com' of the OpenID Connect discovery document does not match")))
+		})
+
+		It("returns an error when the document cannot be fetched", func() {
+			client.Issuer = provider.URL + "/missing"
+
+			_, err := client.Metadata()
+
+			Expect(err).To(MatchError(ContainSubstring("received HTTP 404")))
+		})
+	})
+
+	Context("PasswordGrant()", func() {
+		It("requests a token from the token endpoint", func() {
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.Header.Get("Content-Type")).To(Equal("application/x-www-form-urlencoded"))
+				Expect(r.PostForm.Get("grant_type")).To(Equal("password"))
+				Expect(r.PostForm.Get("username")).To(Equal("some-user"))
+				Expect(r.PostForm.Get("password")).To(Equal("some-password"))
+				Expect(r.PostForm.Get("client_id")).To(Equal("client-id"))
+				Expect(r.PostForm.Get("client_secret")).To(Equal("client-secret"))
+
+				w.Write([]byte(`{"access_token":"access-token","refresh_token":"refresh-token","token_type":"Bearer"}`))
+			})
+
+			accessToken, refreshToken, err := client.PasswordGrant("client-id", "client-secret", "some-user", "some-password")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accessToken).To(Equal("access-token"))
+			Expect(refreshToken).To(Equal("refresh-token"))
+		})
+	})
+
+	Context("ClientCredentialGrant()", func() {
+		It("requests a token from the token endpoint", func() {
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+
+				Expect(r.PostForm.Get("grant_type")).To(Equal("client_credentials"))
+				Expect(r.PostForm.Get("client_id")).To(Equal("client-id"))
+
+				w.Write([]byte(`{"access_token":"access-token","token_type":"Bearer"}`))
+			})
+
+			accessToken, err := client.ClientCredentialGrant("client-id", "client-secret")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accessToken).To(Equal("access-token"))
+		})
+	})
+
+	Context("RefreshTokenGrant()", func() {
+		It("keeps the refresh token when the provider does not return a new one", func() {
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+
+				Expect(r.PostForm.Get("grant_type")).To(Equal("refresh_token"))
+				Expect(r.PostForm.Get("refresh_token")).To(Equal("refresh-token"))
+
+				w.Write([]byte(`{"access_token":"new-access-token","token_type":"Bearer"}`))
+			})
+
+			accessToken, refreshToken, err := client.RefreshTokenGrant("client-id", "client-secret", "refresh-token")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accessToken).To(Equal("new-access-token"))
+			Expect(refreshToken).To(Equal("refresh-token"))
+		})
+
+		It("returns the error of the provider", func() {
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_grant","error_description":"Token is not active"}`))
+			})
+
+			_, _, err := client.RefreshTokenGrant("client-id", "client-secret", "refresh-token")
+
+			Expect(err).To(MatchError("invalid_grant Token is not active"))
+		})
+	})
+
+	Context("RevokeToken()", func() {
+		It("revokes the token at the revocation endpoint", func() {
+			var revoked bool
+			mux.HandleFunc("/revoke", func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.PostForm.Get("token")).To(Equal("access-token"))
+				Expect(r.PostForm.Get("token_type_hint")).To(Equal("access_token"))
+				Expect(r.PostForm.Get("client_id")).To(Equal("client-id"))
+				Expect(r.PostForm.Get("client_secret")).To(Equal("client-secret"))
+
+				revoked = true
+			})
+
+			Expect(client.RevokeToken("access-token")).To(Succeed())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("revokes refresh tokens with the refresh_token hint", func() {
+			var revoked bool
+			mux.HandleFunc("/revoke", func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+
+				Expect(r.PostForm.Get("token")).To(Equal("refresh-token"))
+				Expect(r.PostForm.Get("token_type_hint")).To(Equal("refresh_token"))
+				Expect(r.PostForm.Get("client_id")).To(Equal("client-id"))
+
+				revoked = true
+			})
+
+			Expect(client.RevokeRefreshToken("refresh-token")).To(Succeed())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("returns an error when the provider rejects the request", func() {
+			mux.HandleFunc("/revoke", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			})
+
+			Expect(client.RevokeToken("access-token")).To(MatchError(ContainSubstring("Received HTTP 503 error")))
+		})
+
+		It("returns ErrRevocationNotSupported without a revocation endpoint", func() {
+			discovery = `{"issuer":"%[1]s","token_endpoint":"%[1]s/token"}`
+
+			Expect(client.RevokeToken("access-token")).To(Equal(ErrRevocationNotSupported))
+		})
+	})
+
+	DescribeTable("discovery fails",
+		func(performAction func(*Client) error) {
+			discovery = `{`
+
+			Expect(performAction(client)).To(HaveOccurred())
+		},
+		Entry("client credentials", func(c *Client) error {
+			_, err := c.ClientCredentialGrant("client-id", "client-secret")
+			return err
+		}),
+		Entry("password grant", func(c *Client) error {
+			_, _, err := c.PasswordGrant("client-id", "client-secret", "username", "password")
+			return err
+		}),
+		Entry("refresh token grant", func(c *Client) error {
+			_, _, err := c.RefreshTokenGrant("client-id", "client-secret", "refresh-token")
+			return err
+		}),
+		Entry("revoke token", func(c *Client) error {
+			return c.RevokeToken("access-token")
+		}),
+	)
+})
@@ -0,0 +1,13 @@
+package oidc_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestOidc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Oidc Suite")
+}
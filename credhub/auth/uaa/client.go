@@ -3,6 +3,7 @@ package uaa
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -12,8 +13,21 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
+// DeviceCodeGrantType is the grant type of token requests with a device code,
+// see RFC 8628
+const DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// DefaultDeviceCodeInterval is the time between token requests with a device
+// code when the auth server does not provide an interval
+const DefaultDeviceCodeInterval = 5 * time.Second
+
+// DeviceCodeSlowDown is added to the time between token requests with a device
+// code each time the auth server asks the client to slow down
+const DeviceCodeSlowDown = 5 * time.Second
+
 // Client makes requests to the UAA server at AuthURL
 type Client struct {
 	AuthURL string
@@ -26,6 +40,26 @@ type token struct {
 	TokenType    string `json:"token_type"`
 }
 
+// DeviceAuthorization is returned by a device authorization request. The user
+// approves the request by entering UserCode at VerificationURI.
+type DeviceAuthorization struct {
+	DeviceCode              string
+	UserCode                string
+	VerificationURI         string
+	VerificationURIComplete string
+	ExpiresIn               time.Duration
+	Interval                time.Duration
+}
+
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
 type responseError struct {
 	Name        string `json:"error"`
 	Description string `json:"error_description"`
@@ -155,6 +189,100 @@ func (u *Client) RefreshTokenGrant(clientId, clientSecret, refreshToken string)
 	return token.AccessToken, token.RefreshToken, err
 }
 
+// DeviceAuthorizationRequest starts a device authorization grant, see RFC 8628
+func (u *Client) DeviceAuthorizationRequest(clientId, clientSecret string) (DeviceAuthorization, error) {
+	values := url.Values{
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+	}
+
+	request, _ := http.NewRequest("POST", u.AuthURL+"/oauth/device_authorization", bytes.NewBufferString(values.Encode()))
+	request.Header.Add("Accept", "application/json")
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := u.Client.Do(request)
+	if err != nil {
+		return DeviceAuthorization{}, err
+	}
+
+	defer response.Body.Close()
+	defer io.Copy(ioutil.Discard, response.Body)
+
+	decoder := json.NewDecoder(response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		respErr := responseError{}
+		if err := decoder.Decode(&respErr); err != nil {
+			return DeviceAuthorization{}, err
+		}
+		return DeviceAuthorization{}, &respErr
+	}
+
+	var r deviceAuthorizationResponse
+	if err := decoder.Decode(&r); err != nil {
+		return DeviceAuthorization{}, err
+	}
+	if r.DeviceCode == "" || r.UserCode == "" || r.VerificationURI == "" {
+		return DeviceAuthorization{}, errors.New("the device authorization response is missing the device code, user code or verification URI")
+	}
+
+	return DeviceAuthorization{
+		DeviceCode:              r.DeviceCode,
+		UserCode:                r.UserCode,
+		VerificationURI:         r.VerificationURI,
+		VerificationURIComplete: r.VerificationURIComplete,
+		ExpiresIn:               time.Duration(r.ExpiresIn) * time.Second,
+		Interval:                time.Duration(r.Interval) * time.Second,
+	}, nil
+}
+
+// DeviceCodeGrant requests an access token and refresh token using the device
+// code of authorization. The token endpoint is polled every interval of the
+// authorization until the user approved or denied the request, the device code
+// expired, or ctx is done. The interval is increased by DeviceCodeSlowDown each
+// time the auth server responds with slow_down.
+func (u *Client) DeviceCodeGrant(ctx context.Context, clientId, clientSecret string, authorization DeviceAuthorization) (string, string, error) {
+	values := url.Values{
+		"grant_type":    {DeviceCodeGrantType},
+		"device_code":   {authorization.DeviceCode},
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+	}
+
+	var expired <-chan time.Time
+	if authorization.ExpiresIn > 0 {
+		expired = time.After(authorization.ExpiresIn)
+	}
+
+	interval := authorization.Interval
+	if interval <= 0 {
+		interval = DefaultDeviceCodeInterval
+	}
+
+	for {
+		select {
+		case <-time.After(interval):
+		case <-expired:
+			return "", "", errors.New("the device code expired before the request was approved")
+		case <-ctx.Done():
+			return "", "", ctx.Err()
+		}
+
+		token, err := u.tokenGrantRequest(values)
+		if respErr, ok := err.(*responseError); ok {
+			switch respErr.Name {
+			case "authorization_pending":
+				continue
+			case "slow_down":
+				interval += DeviceCodeSlowDown
+				continue
+			}
+		}
+
+		return token.AccessToken, token.RefreshToken, err
+	}
+}
+
 func (u *Client) tokenGrantRequest(headers url.Values) (token, error) {
 	var t token
 
@@ -230,3 +358,57 @@ func (u *Client) RevokeToken(accessToken string) error {
 
 	return nil
 }
+
+// UserInfo requests the claims of the user the given access token was issued to.
+// The token must have the openid scope.
+func (u *Client) UserInfo(accessToken string) (map[string]interface{}, error) {
+	request, err := http.NewRequest("GET", u.AuthURL+"/userinfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/json")
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+
+	return u.claimsRequest(request)
+}
+
+// CheckToken requests the claims of the given token from the auth server, which
+// authenticates the request with the client credentials
+func (u *Client) CheckToken(clientId, clientSecret, token string) (map[string]interface{}, error) {
+	values := url.Values{"token": {token}}
+
+	request, err := http.NewRequest("POST", u.AuthURL+"/check_token", bytes.NewBufferString(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/json")
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth(clientId, clientSecret)
+
+	return u.claimsRequest(request)
+}
+
+func (u *Client) claimsRequest(request *http.Request) (map[string]interface{}, error) {
+	response, err := u.Client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+	defer io.Copy(ioutil.Discard, response.Body)
+
+	decoder := json.NewDecoder(response.Body)
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		claims := map[string]interface{}{}
+		err = decoder.Decode(&claims)
+		return claims, err
+	}
+
+	respErr := responseError{}
+	if err := decoder.Decode(&respErr); err != nil || respErr.Name == "" {
+		return nil, fmt.Errorf("Received HTTP %d error from auth server", response.StatusCode)
+	}
+
+	return nil, &respErr
+}
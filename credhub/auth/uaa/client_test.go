@@ -1,8 +1,11 @@
 package uaa_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
+	"time"
 
 	. "code.cloudfoundry.org/credhub-cli/credhub/auth/uaa"
 
@@ -259,6 +262,150 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Context("DeviceAuthorizationRequest()", func() {
+		It("should make a device authorization request", func() {
+			uaaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.URL.Path).To(Equal("/oauth/device_authorization"))
+				Expect(r.Header.Get("Content-Type")).To(Equal("application/x-www-form-urlencoded"))
+				Expect(r.PostForm.Get("client_id")).To(Equal("client-id"))
+				Expect(r.PostForm.Get("client_secret")).To(Equal("client-secret"))
+
+				w.Write([]byte(`{
+					"device_code": "device-code",
+					"user_code": "ABCD-EFGH",
+					"verification_uri": "https://uaa.example.com/device",
+					"verification_uri_complete": "https://uaa.example.com/device?user_code=ABCD-EFGH",
+					"expires_in": 600,
+					"interval": 5
+				}`))
+			}))
+			defer uaaServer.Close()
+
+			client := Client{
+				AuthURL: uaaServer.URL,
+				Client:  http.DefaultClient,
+			}
+
+			authorization, err := client.DeviceAuthorizationRequest("client-id", "client-secret")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(authorization).To(Equal(DeviceAuthorization{
+				DeviceCode:              "device-code",
+				UserCode:                "ABCD-EFGH",
+				VerificationURI:         "https://uaa.example.com/device",
+				VerificationURIComplete: "https://uaa.example.com/device?user_code=ABCD-EFGH",
+				ExpiresIn:               10 * time.Minute,
+				Interval:                5 * time.Second,
+			}))
+		})
+
+		It("returns an error when the response is missing the user code", func() {
+			uaaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"device_code":"device-code","verification_uri":"https://uaa.example.com/device"}`))
+			}))
+			defer uaaServer.Close()
+
+			client := Client{
+				AuthURL: uaaServer.URL,
+				Client:  http.DefaultClient,
+			}
+
+			_, err := client.DeviceAuthorizationRequest("client-id", "client-secret")
+
+			Expect(err).To(MatchError(ContainSubstring("missing the device code, user code or verification URI")))
+		})
+	})
+
+	Context("DeviceCodeGrant()", func() {
+		var (
+			responses []string
+			requests  int32
+			client    Client
+			uaaServer *httptest.Server
+		)
+
+		BeforeEach(func() {
+			responses = nil
+			atomic.StoreInt32(&requests, 0)
+
+			uaaServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+
+				Expect(r.URL.Path).To(Equal("/oauth/token"))
+				Expect(r.PostForm.Get("grant_type")).To(Equal("urn:ietf:params:oauth:grant-type:device_code"))
+				Expect(r.PostForm.Get("device_code")).To(Equal("device-code"))
+				Expect(r.PostForm.Get("client_id")).To(Equal("client-id"))
+				Expect(r.PostForm).NotTo(HaveKey("response_type"))
+
+				i := int(atomic.AddInt32(&requests, 1)) - 1
+				if i >= len(responses) {
+					i = len(responses) - 1
+				}
+				if responses[i] == "token" {
+					w.Write([]byte(`{"access_token":"access-token","refresh_token":"refresh-token"}`))
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"` + responses[i] + `"}`))
+			}))
+
+			client = Client{
+				AuthURL: uaaServer.URL,
+				Client:  http.DefaultClient,
+			}
+		})
+
+		AfterEach(func() {
+			uaaServer.Close()
+		})
+
+		authorization := DeviceAuthorization{DeviceCode: "device-code", Interval: 10 * time.Millisecond}
+
+		It("polls the token endpoint until the request is approved", func() {
+			responses = []string{"authorization_pending", "authorization_pending", "token"}
+
+			accessToken, refreshToken, err := client.DeviceCodeGrant(context.Background(), "client-id", "client-secret", authorization)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accessToken).To(Equal("access-token"))
+			Expect(refreshToken).To(Equal("refresh-token"))
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(3)))
+		})
+
+		It("polls less often when the auth server asks to slow down", func() {
+			responses = []string{"slow_down", "token"}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			_, _, err := client.DeviceCodeGrant(ctx, "client-id", "client-secret", authorization)
+
+			Expect(err).To(Equal(context.DeadlineExceeded))
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+		})
+
+		It("returns an error when the request is denied", func() {
+			responses = []string{"authorization_pending", "access_denied"}
+
+			_, _, err := client.DeviceCodeGrant(context.Background(), "client-id", "client-secret", authorization)
+
+			Expect(err).To(MatchError("access_denied"))
+		})
+
+		It("returns an error when the device code expires", func() {
+			responses = []string{"authorization_pending"}
+			expiring := authorization
+			expiring.ExpiresIn = 50 * time.Millisecond
+
+			_, _, err := client.DeviceCodeGrant(context.Background(), "client-id", "client-secret", expiring)
+
+			Expect(err).To(MatchError("the device code expired before the request was approved"))
+		})
+	})
+
 	Context("RevokeToken()", func() {
 		It("requests to revoke the token", func() {
 			token := "e30K.eyJqdGkiOiIxIn0K.e30K" // {}.{"jti":"1"}.{}
@@ -307,6 +454,78 @@ var _ = Describe("Client", func() {
 		)
 	})
 
+	Context("UserInfo()", func() {
+		It("requests the claims of the user with the token", func() {
+			uaaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodGet))
+				Expect(r.URL.Path).To(Equal("/userinfo"))
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer access-token"))
+
+				w.Write([]byte(`{"user_id":"1234","user_name":"some-user"}`))
+			}))
+			defer uaaServer.Close()
+
+			client := Client{
+				AuthURL: uaaServer.URL,
+				Client:  http.DefaultClient,
+			}
+
+			claims, err := client.UserInfo("access-token")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claims).To(Equal(map[string]interface{}{"user_id": "1234", "user_name": "some-user"}))
+		})
+	})
+
+	Context("CheckToken()", func() {
+		It("requests the claims of the token with the client credentials", func() {
+			uaaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.URL.Path).To(Equal("/check_token"))
+				Expect(r.Header.Get("Content-Type")).To(Equal("application/x-www-form-urlencoded"))
+				Expect(r.PostForm.Get("token")).To(Equal("access-token"))
+
+				clientId, clientSecret, ok := r.BasicAuth()
+				Expect(ok).To(BeTrue())
+				Expect(clientId).To(Equal("client-id"))
+				Expect(clientSecret).To(Equal("client-secret"))
+
+				w.Write([]byte(`{"client_id":"some-client","scope":["credhub.read"]}`))
+			}))
+			defer uaaServer.Close()
+
+			client := Client{
+				AuthURL: uaaServer.URL,
+				Client:  http.DefaultClient,
+			}
+
+			claims, err := client.CheckToken("client-id", "client-secret", "access-token")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claims).To(HaveKeyWithValue("client_id", "some-client"))
+			Expect(claims).To(HaveKeyWithValue("scope", []interface{}{"credhub.read"}))
+		})
+
+		It("returns the error of the auth server", func() {
+			uaaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_token","error_description":"Token has expired"}`))
+			}))
+			defer uaaServer.Close()
+
+			client := Client{
+				AuthURL: uaaServer.URL,
+				Client:  http.DefaultClient,
+			}
+
+			_, err := client.CheckToken("client-id", "client-secret", "access-token")
+
+			Expect(err).To(MatchError("invalid_token Token has expired"))
+		})
+	})
+
 	DescribeTable("unable to complete the request",
 		func(performAction func(*Client) error) {
 			uaaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -342,6 +561,14 @@ var _ = Describe("Client", func() {
 		Entry("revoke token", func(c *Client) error {
 			return c.RevokeToken("e30K.eyJqdGkiOiIxIn0K.e30K") // {}.{"jti":"1"}.{}
 		}),
+		Entry("user info", func(c *Client) error {
+			_, err := c.UserInfo("access-token")
+			return err
+		}),
+		Entry("check token", func(c *Client) error {
+			_, err := c.CheckToken("client-id", "client-secret", "access-token")
+			return err
+		}),
 	)
 
 	DescribeTable("response body is invalid",
@@ -373,6 +600,14 @@ var _ = Describe("Client", func() {
 			_, _, err := c.RefreshTokenGrant("client-id", "client-secret", "some-refresh-token")
 			return err
 		}),
+		Entry("user info", func(c *Client) error {
+			_, err := c.UserInfo("access-token")
+			return err
+		}),
+		Entry("check token", func(c *Client) error {
+			_, err := c.CheckToken("client-id", "client-secret", "access-token")
+			return err
+		}),
 	)
 
 	DescribeTable("credentials are invalid",
@@ -28,5 +28,9 @@ func (ch *CredHub) BulkRegenerate(signedBy string) (credentials.BulkRegenerateRe
 	dec := json.NewDecoder(resp.Body)
 	err = dec.Decode(&creds)
 
+	for _, name := range creds.Certificates {
+		ch.invalidateCache(name)
+	}
+
 	return creds, err
 }
@@ -0,0 +1,214 @@
+package credhub
+
+import (
+	"container/list"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStats reports how reads were served by the cache enabled with the Cache option.
+type CacheStats struct {
+	// Hits is the number of reads served from fresh cache entries
+	Hits int
+
+	// StaleHits is the number of reads served from expired entries while they were refreshed
+	StaleHits int
+
+	// Misses is the number of reads that were sent to the CredHub server
+	Misses int
+
+	// Entries is the number of credentials currently cached
+	Entries int
+}
+
+// readCache holds the JSON of credentials read by name or ID, evicting the
+// least recently used entry when it is full
+type readCache struct {
+	ttl        time.Duration
+	maxEntries int
+	maxStale   time.Duration
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List
+	generation int
+	stats      CacheStats
+}
+
+type readCacheEntry struct {
+	key        string
+	name       string
+	value      json.RawMessage
+	expires    time.Time
+	refreshing bool
+}
+
+func newReadCache() *readCache {
+	return &readCache{
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+// CacheStats returns the statistics of the read cache. All values are zero
+// when the Cache option was not provided.
+func (ch *CredHub) CacheStats() CacheStats {
+	if ch.cache == nil {
+		return CacheStats{}
+	}
+
+	ch.cache.mu.Lock()
+	defer ch.cache.mu.Unlock()
+
+	stats := ch.cache.stats
+	stats.Entries = ch.cache.lru.Len()
+	return stats
+}
+
+func (ch *CredHub) cacheEnabled() bool {
+	return ch.cache != nil && ch.cache.ttl > 0
+}
+
+// invalidateCache removes all cached versions of the named credential.
+// It is called after every request through this client that changes the credential.
+func (ch *CredHub) invalidateCache(name string) {
+	if ch.cache != nil {
+		ch.cache.invalidate(name)
+	}
+}
+
+func nameCacheKey(name string) string {
+	return "name:" + normalizeCacheName(name)
+}
+
+func idCacheKey(id string) string {
+	return "id:" + id
+}
+
+func normalizeCacheName(name string) string {
+	return "/" + strings.TrimPrefix(name, "/")
+}
+
+// get returns the cached value for key, or stores the value returned by fetch.
+// Expired entries are served while they are refreshed in the background, as
+// long as they expired less than maxStale ago.
+func (c *readCache) get(key string, fetch func() (json.RawMessage, error)) (json.RawMessage, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*readCacheEntry)
+
+		if now.Before(entry.expires) {
+			c.stats.Hits++
+			c.lru.MoveToFront(element)
+			c.mu.Unlock()
+			return entry.value, nil
+		}
+
+		if now.Before(entry.expires.Add(c.maxStale)) {
+			c.stats.StaleHits++
+			c.lru.MoveToFront(element)
+			if !entry.refreshing {
+				entry.refreshing = true
+				go c.refresh(key, fetch, c.generation)
+			}
+			c.mu.Unlock()
+			return entry.value, nil
+		}
+
+		c.removeElement(element)
+	}
+	c.stats.Misses++
+	generation := c.generation
+	c.mu.Unlock()
+
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.store(key, value, generation)
+	c.mu.Unlock()
+
+	return value, nil
+}
+
+// refresh replaces an expired entry. The entry is kept until it is too stale
+// to be served when the CredHub server cannot be reached, or when the value
+// cannot be stored as the cache was invalidated since it was requested, so
+// that the next read of the entry refreshes it again.
+func (c *readCache) refresh(key string, fetch func() (json.RawMessage, error), generation int) {
+	value, err := fetch()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil && c.store(key, value, generation) {
+		return
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*readCacheEntry).refreshing = false
+	}
+}
+
+// store adds value to the cache, unless the cache was invalidated since the
+// value was requested, and reports whether it was added. The caller must hold
+// the lock.
+func (c *readCache) store(key string, value json.RawMessage, generation int) bool {
+	if generation != c.generation {
+		return false
+	}
+
+	var cred struct {
+		Name string `json:"name"`
+	}
+	json.Unmarshal(value, &cred)
+
+	entry := &readCacheEntry{
+		key:     key,
+		name:    normalizeCacheName(cred.Name),
+		value:   value,
+		expires: time.Now().Add(c.ttl),
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return true
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.removeElement(c.lru.Back())
+	}
+	return true
+}
+
+func (c *readCache) invalidate(name string) {
+	name = normalizeCacheName(name)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+
+	for _, element := range c.entries {
+		if element.Value.(*readCacheEntry).name == name {
+			c.removeElement(element)
+		}
+	}
+	if element, ok := c.entries[nameCacheKey(name)]; ok {
+		c.removeElement(element)
+	}
+}
+
+func (c *readCache) removeElement(element *list.Element) {
+	delete(c.entries, element.Value.(*readCacheEntry).key)
+	c.lru.Remove(element)
+}
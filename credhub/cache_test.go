@@ -0,0 +1,255 @@
+package credhub_test
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	. "code.cloudfoundry.org/credhub-cli/credhub"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Cache", func() {
+	var server *ghttp.Server
+
+	credentialJSON := func(id, name, value string) string {
+		return `{"id":"` + id + `","name":"` + name + `","type":"password","value":"` + value + `"}`
+	}
+
+	respondByName := func(name, id, value string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/data", "current=true&name="+name),
+			ghttp.RespondWith(http.StatusOK, `{"data":[`+credentialJSON(id, name, value)+`]}`),
+		)
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("serves repeated reads by name from the cache", func() {
+		server.AppendHandlers(respondByName("/example-password", "id-1", "first"))
+
+		ch, _ := New(server.URL(), Cache(time.Minute, 10))
+
+		cred, err := ch.GetLatestVersion("/example-password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cred.Value).To(Equal("first"))
+
+		password, err := ch.GetLatestPassword("example-password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(password.Value)).To(Equal("first"))
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(ch.CacheStats()).To(Equal(CacheStats{Hits: 1, Misses: 1, Entries: 1}))
+	})
+
+	It("serves repeated reads by ID from the cache", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/data/id-1"),
+			ghttp.RespondWith(http.StatusOK, credentialJSON("id-1", "/example-password", "first")),
+		))
+
+		ch, _ := New(server.URL(), Cache(time.Minute, 10))
+
+		for i := 0; i < 2; i++ {
+			cred, err := ch.GetById("id-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cred.Value).To(Equal("first"))
+		}
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("does not cache errors", func() {
+		server.AppendHandlers(
+			ghttp.RespondWith(http.StatusNotFound, `{"error":"The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`),
+			respondByName("/example-password", "id-1", "first"),
+		)
+
+		ch, _ := New(server.URL(), Cache(time.Minute, 10))
+
+		_, err := ch.GetLatestVersion("/example-password")
+		Expect(err).To(HaveOccurred())
+
+		cred, err := ch.GetLatestVersion("/example-password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cred.Value).To(Equal("first"))
+	})
+
+	It("reads the credential again once it has expired", func() {
+		server.AppendHandlers(
+			respondByName("/example-password", "id-1", "first"),
+			respondByName("/example-password", "id-2", "second"),
+		)
+
+		ch, _ := New(server.URL(), Cache(10*time.Millisecond, 10))
+
+		ch.GetLatestVersion("/example-password")
+		time.Sleep(20 * time.Millisecond)
+
+		cred, err := ch.GetLatestVersion("/example-password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cred.Value).To(Equal("second"))
+		Expect(ch.CacheStats().Misses).To(Equal(2))
+	})
+
+	It("evicts the least recently used credential when it is full", func() {
+		server.AppendHandlers(
+			respondByName("/first", "id-1", "first"),
+			respondByName("/second", "id-2", "second"),
+			respondByName("/third", "id-3", "third"),
+			respondByName("/second", "id-2", "second"),
+		)
+
+		ch, _ := New(server.URL(), Cache(time.Minute, 2))
+
+		ch.GetLatestVersion("/first")
+		ch.GetLatestVersion("/second")
+		ch.GetLatestVersion("/first")
+		ch.GetLatestVersion("/third")
+		ch.GetLatestVersion("/first")
+		ch.GetLatestVersion("/second")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(4))
+		Expect(ch.CacheStats().Entries).To(Equal(2))
+	})
+
+	Context("when the credential is changed through the same client", func() {
+		It("removes the credential after setting it", func() {
+			server.AppendHandlers(
+				respondByName("/example-password", "id-1", "first"),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/data"),
+					ghttp.RespondWith(http.StatusOK, credentialJSON("id-2", "/example-password", "second")),
+				),
+				respondByName("/example-password", "id-2", "second"),
+			)
+
+			ch, _ := New(server.URL(), Cache(time.Minute, 10), ServerVersion("2.0.0"))
+
+			ch.GetLatestVersion("/example-password")
+			_, err := ch.SetCredential("example-password", "password", "second")
+			Expect(err).NotTo(HaveOccurred())
+
+			cred, err := ch.GetLatestVersion("/example-password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cred.Value).To(Equal("second"))
+		})
+
+		It("removes all versions after deleting it", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, credentialJSON("id-1", "/example-password", "first")),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/data", "name=example-password"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+				ghttp.RespondWith(http.StatusNotFound, `{"error":"The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`),
+			)
+
+			ch, _ := New(server.URL(), Cache(time.Minute, 10))
+
+			_, err := ch.GetById("id-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ch.Delete("example-password")).To(Succeed())
+
+			_, err = ch.GetById("id-1")
+			Expect(err).To(HaveOccurred())
+			Expect(ch.CacheStats().Entries).To(Equal(0))
+		})
+	})
+
+	Context("with CacheStaleWhileRevalidate", func() {
+		It("serves the expired credential while it is refreshed", func() {
+			server.AppendHandlers(
+				respondByName("/example-password", "id-1", "first"),
+				respondByName("/example-password", "id-2", "second"),
+			)
+
+			ch, _ := New(server.URL(), Cache(10*time.Millisecond, 10), CacheStaleWhileRevalidate(time.Hour))
+
+			ch.GetLatestVersion("/example-password")
+			time.Sleep(20 * time.Millisecond)
+
+			cred, err := ch.GetLatestVersion("/example-password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cred.Value).To(Equal("first"))
+			Expect(ch.CacheStats().StaleHits).To(Equal(1))
+
+			Eventually(func() interface{} {
+				cred, _ := ch.GetLatestVersion("/example-password")
+				return cred.Value
+			}).Should(Equal("second"))
+		})
+
+		It("refreshes the expired credential again when another credential was changed during the refresh", func() {
+			release := make(chan struct{})
+			var reads int32
+			server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+				switch atomic.AddInt32(&reads, 1) {
+				case 1:
+					w.Write([]byte(`{"data":[` + credentialJSON("id-1", "/example-password", "first") + `]}`))
+				case 2:
+					<-release
+					w.Write([]byte(`{"data":[` + credentialJSON("id-2", "/example-password", "second") + `]}`))
+				default:
+					w.Write([]byte(`{"data":[` + credentialJSON("id-3", "/example-password", "third") + `]}`))
+				}
+			})
+			server.RouteToHandler("DELETE", "/api/v1/data", ghttp.RespondWith(http.StatusNoContent, ""))
+
+			ch, _ := New(server.URL(), Cache(50*time.Millisecond, 10), CacheStaleWhileRevalidate(time.Hour))
+
+			ch.GetLatestVersion("/example-password")
+			time.Sleep(60 * time.Millisecond)
+
+			cred, err := ch.GetLatestVersion("/example-password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cred.Value).To(Equal("first"))
+			Eventually(func() int32 { return atomic.LoadInt32(&reads) }).Should(Equal(int32(2)))
+
+			Expect(ch.Delete("/other-password")).To(Succeed())
+			close(release)
+
+			Eventually(func() interface{} {
+				cred, _ := ch.GetLatestVersion("/example-password")
+				return cred.Value
+			}).Should(Equal("third"))
+		})
+
+		It("serves the expired credential when the server cannot be reached", func() {
+			server.AppendHandlers(respondByName("/example-password", "id-1", "first"))
+
+			ch, _ := New(server.URL(), Cache(10*time.Millisecond, 10), CacheStaleWhileRevalidate(time.Hour))
+
+			ch.GetLatestVersion("/example-password")
+			server.Close()
+			time.Sleep(20 * time.Millisecond)
+
+			for i := 0; i < 3; i++ {
+				cred, err := ch.GetLatestVersion("/example-password")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cred.Value).To(Equal("first"))
+			}
+		})
+	})
+
+	It("returns zero statistics without a cache", func() {
+		ch, _ := New(server.URL())
+
+		Expect(ch.CacheStats()).To(Equal(CacheStats{}))
+	})
+
+	It("requires a positive ttl", func() {
+		_, err := New(server.URL(), Cache(0, 10))
+
+		Expect(err).To(MatchError("cache ttl must be positive"))
+	})
+})
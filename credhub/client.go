@@ -6,8 +6,6 @@ import (
 	"net"
 	"net/http"
 	"time"
-
-	"github.com/cloudfoundry/socks5-proxy"
 )
 
 // Client provides an unauthenticated http.Client to the CredHub server
@@ -20,11 +18,42 @@ func (ch *CredHub) Client() *http.Client {
 }
 
 func (ch *CredHub) client() *http.Client {
-	if ch.baseURL.Scheme == "https" {
-		return httpsClient(ch.insecureSkipVerify, ch.caCerts, ch.clientCertificate)
+	var client *http.Client
+
+	if ch.httpClient != nil {
+		clone := *ch.httpClient
+		client = &clone
+	} else if ch.baseURL.Scheme == "https" {
+		client = httpsClient(ch.insecureSkipVerify, ch.caCerts, ch.clientCertificate, ch.proxyDial)
+	} else {
+		client = httpClient()
+		client.Transport = &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			Dial:                ch.proxyDial,
+			MaxIdleConnsPerHost: 100,
+		}
+	}
+
+	if len(ch.middleware) > 0 {
+		transport := client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		for i := len(ch.middleware) - 1; i >= 0; i-- {
+			transport = ch.middleware[i](transport)
+		}
+		client.Transport = transport
 	}
 
-	return httpClient()
+	if logger := ch.logger(); logger != nil {
+		transport := client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		client.Transport = &tokenLogTransport{logger: logger, next: transport}
+	}
+
+	return client
 }
 
 func httpClient() *http.Client {
@@ -35,7 +64,7 @@ func httpClient() *http.Client {
 
 var defaultDialer net.Dialer
 
-func httpsClient(insecureSkipVerify bool, rootCAs *x509.CertPool, cert *tls.Certificate) *http.Client {
+func httpsClient(insecureSkipVerify bool, rootCAs *x509.CertPool, cert *tls.Certificate, dial DialFunc) *http.Client {
 	client := httpClient()
 
 	var certs []tls.Certificate
@@ -43,11 +72,6 @@ func httpsClient(insecureSkipVerify bool, rootCAs *x509.CertPool, cert *tls.Cert
 		certs = []tls.Certificate{*cert}
 	}
 
-	var dialer = SOCKS5DialFuncFromEnvironment((&net.Dialer{
-		Timeout:   30 * time.Second,
-		KeepAlive: 30 * time.Second,
-	}).Dial, proxy.NewSocks5Proxy(proxy.NewHostKey(), nil))
-
 	client.Transport = &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify:       insecureSkipVerify,
@@ -56,9 +80,28 @@ func httpsClient(insecureSkipVerify bool, rootCAs *x509.CertPool, cert *tls.Cert
 			RootCAs:                  rootCAs,
 		},
 		Proxy:               http.ProxyFromEnvironment,
-		Dial:                dialer,
+		Dial:                dial,
 		MaxIdleConnsPerHost: 100,
 	}
 
 	return client
 }
+
+// proxyDialer wraps the dialer provided with the Dialer option, or the default
+// dialer, with the proxy provided with the Proxy option or the environment
+func (ch *CredHub) proxyDialer() (DialFunc, error) {
+	dial := ch.dialer
+	if dial == nil {
+		dial = (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).Dial
+	}
+
+	proxyURL := ch.proxyURL
+	if proxyURL == "" {
+		proxyURL = ProxyFromEnvironment()
+	}
+
+	return ProxyDialFunc(proxyURL, dial, nil)
+}
@@ -2,7 +2,9 @@ package credhub_test
 
 import (
 	"crypto/x509"
+	"errors"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"runtime"
 	"time"
@@ -11,6 +13,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	. "code.cloudfoundry.org/credhub-cli/credhub"
+	"code.cloudfoundry.org/credhub-cli/credhub/auth"
+	"github.com/onsi/gomega/ghttp"
 )
 
 var _ = Describe("Client()", func() {
@@ -33,6 +37,22 @@ var _ = Describe("Client()", func() {
 		})
 	})
 
+	Context("With Dialer", func() {
+		It("opens connections with the provided dial function", func() {
+			dialed := ""
+			ch, err := New("http://example.com", Dialer(func(network, address string) (net.Conn, error) {
+				dialed = network + " " + address
+				return nil, errors.New("dial failed")
+			}))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = ch.Client().Get("http://example.com/info")
+
+			Expect(err).To(MatchError(ContainSubstring("dial failed")))
+			Expect(dialed).To(Equal("tcp example.com:80"))
+		})
+	})
+
 	Context("With ClientCert", func() {
 		It("should return a http.Client with tls.Config with client cert", func() {
 			ch, err := New("https://example.com", ClientCert("./fixtures/auth-tls-cert.pem", "./fixtures/auth-tls-key.pem"))
@@ -133,5 +153,95 @@ var _ = Describe("Client()", func() {
 			dial := transport.Dial
 			Expect(dial).NotTo(BeNil())
 		})
+
+		It("should use a dial function for http targets", func() {
+			ch, _ := New("http://example.com")
+			client := ch.Client()
+
+			transport := client.Transport.(*http.Transport)
+			Expect(transport.Dial).NotTo(BeNil())
+			Expect(transport.Proxy).NotTo(BeNil())
+		})
+	})
+
+	Context("With HTTPClient", func() {
+		It("uses a copy of the provided client", func() {
+			provided := &http.Client{Timeout: time.Second}
+			ch, err := New("https://example.com", HTTPClient(provided), Middleware(func(next http.RoundTripper) http.RoundTripper {
+				return next
+			}))
+			Expect(err).NotTo(HaveOccurred())
+
+			client := ch.Client()
+
+			Expect(client.Timeout).To(Equal(time.Second))
+			Expect(client).NotTo(BeIdenticalTo(provided))
+			Expect(provided.Transport).To(BeNil())
+		})
+
+		It("does not allow a nil client", func() {
+			_, err := New("https://example.com", HTTPClient(nil))
+
+			Expect(err).To(MatchError("http client must not be nil"))
+		})
+	})
+
+	Context("With Middleware", func() {
+		var server *ghttp.Server
+
+		headerMiddleware := func(value string) func(http.RoundTripper) http.RoundTripper {
+			return func(next http.RoundTripper) http.RoundTripper {
+				return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					req.Header.Add("X-Middleware", value)
+					return next.RoundTrip(req)
+				})
+			}
+		}
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("wraps the transport of requests to the CredHub server, first middleware outermost", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/data", "current=true&name=%2Fexample-password"),
+				func(w http.ResponseWriter, req *http.Request) {
+					Expect(req.Header["X-Middleware"]).To(Equal([]string{"first", "second"}))
+				},
+				ghttp.RespondWith(http.StatusOK, `{"data":[{"id":"some-id","name":"/example-password","type":"password","value":"some-password"}]}`),
+			))
+
+			ch, _ := New(server.URL(), Middleware(headerMiddleware("first")), Middleware(headerMiddleware("second")))
+
+			_, err := ch.GetLatestVersion("/example-password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("wraps the transport of requests to the auth server", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/oauth/token"),
+				ghttp.VerifyHeaderKV("X-Middleware", "uaa"),
+				ghttp.RespondWith(http.StatusOK, `{"access_token":"some-token","token_type":"bearer"}`),
+			))
+
+			ch, _ := New("https://example.com",
+				AuthURL(server.URL()),
+				Auth(auth.UaaClientCredentials("client-id", "client-secret")),
+				Middleware(headerMiddleware("uaa")),
+			)
+
+			err := ch.Auth.(*auth.OAuthStrategy).Login()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
 	})
 })
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -2,8 +2,7 @@ package credentials
 
 import (
 	"encoding/json"
-
-	"code.cloudfoundry.org/credhub-cli/errors"
+	"errors"
 )
 
 func (c Credential) MarshalYAML() (interface{}, error) {
@@ -32,7 +31,7 @@ func (c Credential) convertToMap() (map[string]interface{}, error) {
 	} else {
 		value, ok := c.Value.(interface{})
 		if !ok {
-			return nil, errors.NewCatchAllError()
+			return nil, errors.New("The targeted API was unable to perform the request. Please validate and retry your request.")
 		}
 		result["value"] = value
 	}
@@ -42,6 +42,27 @@ type CredHub struct {
 	// Skip certificate verification of TLS connections to CredHub and auth servers. Not recommended!
 	insecureSkipVerify bool
 
+	// Client used instead of the one built from the TLS and dialer options
+	httpClient *http.Client
+
+	// Wrappers of the transport of the client, outermost first
+	middleware []func(http.RoundTripper) http.RoundTripper
+
+	// Function used to open connections to the CredHub server, instead of the default dialer
+	dialer DialFunc
+
+	// Proxy provided with the Proxy option, instead of the one from the environment
+	proxyURL string
+
+	// Function used to open connections through the proxy, built by New
+	proxyDial DialFunc
+
+	// Logger for requests to the CredHub server, provided with the Logger option
+	requestLogger LeveledLogger
+
+	// Cache of credentials read by name or ID. Nil unless the Cache option is provided
+	cache *readCache
+
 	authBuilder auth.Builder
 	authURL     *url.URL
 
@@ -9,6 +9,9 @@ import (
 func (ch *CredHub) Delete(name string) error {
 	query := url.Values{}
 	query.Set("name", name)
+
+	defer ch.invalidateCache(name)
+
 	resp, err := ch.Request(http.MethodDelete, "/api/v1/data", query, nil, true)
 
 	if err == nil {
@@ -1,11 +1,28 @@
 package credhub
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/credhub-cli/credhub/auth"
+	"code.cloudfoundry.org/credhub-cli/credhub/network"
+)
 
 // Error provides errors for the CredHub client
+//
+// Errors returned by the CredHub server are wrapped in one of NotFoundError,
+// UnauthorizedError, ForbiddenError, ConflictError or ValidationError, depending on
+// the status code. Use errors.As to get the Error from any of them.
 type Error struct {
 	Name        string `json:"error"`
 	Description string `json:"error_description"`
+
+	// StatusCode of the response from the CredHub server
+	StatusCode int `json:"-"`
+
+	// Method and Path of the request that failed
+	Method string `json:"-"`
+	Path   string `json:"-"`
 }
 
 func (e *Error) Error() string {
@@ -14,3 +31,77 @@ func (e *Error) Error() string {
 	}
 	return fmt.Sprintf("%s: %s", e.Name, e.Description)
 }
+
+// serverError lets the typed errors below embed *Error without their Error()
+// method being hidden by a field of the same name
+type serverError = Error
+
+// NotFoundError is returned when the credential does not exist or the client
+// is not authorized to read it (404)
+type NotFoundError struct{ *serverError }
+
+// UnauthorizedError is returned when the CredHub server rejects the access token (401)
+type UnauthorizedError struct{ *serverError }
+
+// ForbiddenError is returned when the client is not permitted to perform the request (403)
+type ForbiddenError struct{ *serverError }
+
+// ConflictError is returned when the request conflicts with the current state of
+// the credential (409 or 412)
+type ConflictError struct{ *serverError }
+
+// ValidationError is returned when the CredHub server rejects the request as
+// invalid (400 or 422)
+type ValidationError struct{ *serverError }
+
+func (e *NotFoundError) Unwrap() error     { return e.serverError }
+func (e *UnauthorizedError) Unwrap() error { return e.serverError }
+func (e *ForbiddenError) Unwrap() error    { return e.serverError }
+func (e *ConflictError) Unwrap() error     { return e.serverError }
+func (e *ValidationError) Unwrap() error   { return e.serverError }
+
+// NetworkError is returned when a request could not be sent to the server or
+// no response was received
+type NetworkError = network.Error
+
+// AuthError is returned when an access token cannot be obtained or refreshed
+type AuthError = auth.Error
+
+// VersionConflictError is returned when a credential is only set if its current
+// version is the expected one, and the current version is a different one
+type VersionConflictError struct {
+	*ConflictError
+
+	ExpectedId string
+	CurrentId  string
+}
+
+func (e *VersionConflictError) Unwrap() error { return e.ConflictError }
+
+func newVersionConflictError(name, expectedId, currentId string) error {
+	return &VersionConflictError{
+		ConflictError: &ConflictError{&Error{
+			Name: fmt.Sprintf("The credential '%s' was changed by another client: expected version '%s' but the current version is '%s'. Please retry your request.", name, expectedId, currentId),
+		}},
+		ExpectedId: expectedId,
+		CurrentId:  currentId,
+	}
+}
+
+// newStatusError wraps an error returned by the CredHub server in the type for its status code
+func newStatusError(e *Error) error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return &NotFoundError{e}
+	case http.StatusUnauthorized:
+		return &UnauthorizedError{e}
+	case http.StatusForbidden:
+		return &ForbiddenError{e}
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return &ConflictError{e}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &ValidationError{e}
+	default:
+		return e
+	}
+}
@@ -0,0 +1,230 @@
+package credhub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Lookup returns the value of the credential with the given name.
+type Lookup func(name string) (interface{}, error)
+
+// Escaper converts a credential value into the text that replaces its placeholder.
+type Escaper func(value interface{}) (string, error)
+
+var placeholderRegex = regexp.MustCompile(`\(\((!?[-/\.\w\pL]+)\)\)`)
+
+// RawEscaper substitutes strings and numbers as-is. Other values are encoded as JSON.
+func RawEscaper(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	case int, int64, bool:
+		return fmt.Sprintf("%v", v), nil
+	}
+
+	s, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// JSONEscaper escapes strings so they can be placed inside a JSON string literal.
+// Other values are encoded as JSON.
+func JSONEscaper(value interface{}) (string, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return "", err
+	}
+
+	s := strings.TrimSuffix(buf.String(), "\n")
+	if _, ok := value.(string); ok {
+		return s[1 : len(s)-1], nil
+	}
+	return s, nil
+}
+
+// ShellEscaper quotes the raw value so that a POSIX shell treats it as a single word.
+func ShellEscaper(value interface{}) (string, error) {
+	s, err := RawEscaper(value)
+	if err != nil {
+		return "", err
+	}
+	return "'" + strings.Replace(s, "'", `'"'"'`, -1) + "'", nil
+}
+
+// Expand replaces ((name)) and ((name.field)) placeholders in text with the
+// current values of the referenced credentials. Values are substituted as raw strings.
+//
+// Use ExpandText to control how names are resolved and how values are escaped.
+func (ch *CredHub) Expand(text string) (string, error) {
+	return ExpandText(text, ch.lookupLatestValue, RawEscaper)
+}
+
+func (ch *CredHub) lookupLatestValue(name string) (interface{}, error) {
+	cred, err := ch.GetLatestVersion(name)
+	return cred.Value, err
+}
+
+// ExpandText replaces ((name)) and ((name.field)) placeholders in text, resolving
+// each credential name with lookup and formatting each value with escape.
+//
+// Placeholders may select nested fields of map values, e.g. ((my-cert.private_key)).
+// Each credential is looked up once, no matter how often it is referenced.
+func ExpandText(text string, lookup Lookup, escape Escaper) (string, error) {
+	values := map[string]interface{}{}
+
+	var expandErr error
+	result := placeholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if expandErr != nil {
+			return placeholder
+		}
+
+		ref := strings.TrimPrefix(placeholderRegex.FindStringSubmatch(placeholder)[1], "!")
+		fields := strings.Split(ref, ".")
+		name := fields[0]
+
+		value, ok := values[name]
+		if !ok {
+			var err error
+			value, err = lookup(name)
+			if err != nil {
+				expandErr = fmt.Errorf("Finding variable '%s': %s", ref, err)
+				return placeholder
+			}
+			values[name] = value
+		}
+
+		for _, field := range fields[1:] {
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				expandErr = fmt.Errorf("Finding variable '%s': field '%s' not found", ref, field)
+				return placeholder
+			}
+			if value, ok = m[field]; !ok {
+				expandErr = fmt.Errorf("Finding variable '%s': field '%s' not found", ref, field)
+				return placeholder
+			}
+		}
+
+		s, err := escape(value)
+		if err != nil {
+			expandErr = fmt.Errorf("Formatting variable '%s': %s", ref, err)
+			return placeholder
+		}
+		return s
+	})
+
+	if expandErr != nil {
+		return "", expandErr
+	}
+
+	return result, nil
+}
+
+// ExpandJSON replaces ((name)) and ((name.field)) placeholders in the strings of
+// the JSON document data, resolving each credential name with lookup. A string
+// that is a single placeholder is replaced by the value, e.g. an object for a
+// certificate. Placeholders within other strings are substituted as raw
+// strings. The order of object keys and the numbers of data are kept as they are.
+func ExpandJSON(data []byte, lookup Lookup) ([]byte, error) {
+	values := map[string]interface{}{}
+	cachedLookup := func(name string) (interface{}, error) {
+		if value, ok := values[name]; ok {
+			return value, nil
+		}
+		value, err := lookup(name)
+		if err == nil {
+			values[name] = value
+		}
+		return value, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+
+	// containers holds, for each object and array the decoder is in, whether
+	// it is an object and the number of keys and values read from it
+	type container struct {
+		object bool
+		tokens int
+	}
+	var containers []container
+
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			containers = containers[:len(containers)-1]
+			buf.WriteRune(rune(delim))
+			continue
+		}
+
+		isKey := false
+		if len(containers) > 0 {
+			c := &containers[len(containers)-1]
+			isKey = c.object && c.tokens%2 == 0
+			if c.object && !isKey {
+				buf.WriteByte(':')
+			} else if c.tokens > 0 {
+				buf.WriteByte(',')
+			}
+			c.tokens++
+		}
+
+		var value interface{} = token
+		switch t := token.(type) {
+		case json.Delim:
+			buf.WriteRune(rune(t))
+			containers = append(containers, container{object: t == '{'})
+			continue
+		case string:
+			if value, err = expandJSONString(t, isKey, cachedLookup); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := enc.Encode(value); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// expandJSONString returns the value of s when it is a single placeholder and
+// not a key, and s with its placeholders substituted as raw strings otherwise
+func expandJSONString(s string, isKey bool, lookup Lookup) (interface{}, error) {
+	if isKey || placeholderRegex.FindString(s) != s {
+		return ExpandText(s, lookup, RawEscaper)
+	}
+
+	var value interface{}
+	_, err := ExpandText(s, lookup, func(v interface{}) (string, error) {
+		value = v
+		return "", nil
+	})
+	return value, err
+}
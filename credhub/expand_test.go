@@ -0,0 +1,142 @@
+package credhub_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	. "code.cloudfoundry.org/credhub-cli/credhub"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Expand", func() {
+	var lookup Lookup
+	var lookups []string
+
+	BeforeEach(func() {
+		lookups = nil
+		lookup = func(name string) (interface{}, error) {
+			lookups = append(lookups, name)
+			switch name {
+			case "/db/password":
+				return `it's "secret"`, nil
+			case "/db/cert":
+				return map[string]interface{}{"ca": "some-ca", "private_key": "some-key"}, nil
+			case "port":
+				return float64(5432), nil
+			case "max_connections":
+				return float64(1000000), nil
+			}
+			return nil, errors.New("response did not contain any credentials")
+		}
+	})
+
+	Describe("ExpandText()", func() {
+		It("substitutes placeholders anywhere in the text as raw strings", func() {
+			text := "PASSWORD=((/db/password))\nPORT=((port))\nKEY=((/db/cert.private_key))\n"
+
+			result, err := ExpandText(text, lookup, RawEscaper)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("PASSWORD=it's \"secret\"\nPORT=5432\nKEY=some-key\n"))
+		})
+
+		It("substitutes large numbers without an exponent", func() {
+			result, err := ExpandText("MAX=((max_connections))", lookup, RawEscaper)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("MAX=1000000"))
+		})
+
+		It("looks up each credential once", func() {
+			_, err := ExpandText("((/db/cert.ca)) ((/db/cert.private_key)) ((/db/cert))", lookup, RawEscaper)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lookups).To(Equal([]string{"/db/cert"}))
+		})
+
+		It("encodes map values as JSON", func() {
+			result, err := ExpandText("((/db/cert))", lookup, RawEscaper)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(MatchJSON(`{"ca":"some-ca","private_key":"some-key"}`))
+		})
+
+		It("escapes values as JSON string content with the JSONEscaper", func() {
+			result, err := ExpandText(`{"password":"((/db/password))","port":((port))}`, lookup, JSONEscaper)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(MatchJSON(`{"password":"it's \"secret\"","port":5432}`))
+		})
+
+		It("quotes values as shell words with the ShellEscaper", func() {
+			result, err := ExpandText("export PASSWORD=((/db/password))", lookup, ShellEscaper)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(`export PASSWORD='it'"'"'s "secret"'`))
+		})
+
+		It("returns an error when a credential can not be found", func() {
+			_, err := ExpandText("((/missing))", lookup, RawEscaper)
+
+			Expect(err).To(MatchError("Finding variable '/missing': response did not contain any credentials"))
+		})
+
+		It("returns an error when a field can not be found", func() {
+			_, err := ExpandText("((/db/cert.certificate))", lookup, RawEscaper)
+
+			Expect(err).To(MatchError("Finding variable '/db/cert.certificate': field 'certificate' not found"))
+		})
+	})
+
+	Describe("ExpandJSON()", func() {
+		It("replaces placeholders in strings and keeps the order of the keys", func() {
+			data := `{"z": "((/db/cert))", "a": {"password": "((/db/password))", "url": "db:((port))"}, "m": [1e2, 10000000, "((max_connections))"]}`
+
+			result, err := ExpandJSON([]byte(data), lookup)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(result)).To(Equal(`{"z":{"ca":"some-ca","private_key":"some-key"},"a":{"password":"it's \"secret\"","url":"db:5432"},"m":[1e2,10000000,1000000]}`))
+		})
+
+		It("looks up each credential once", func() {
+			_, err := ExpandJSON([]byte(`["((/db/cert.ca))", "((/db/cert.private_key))"]`), lookup)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lookups).To(Equal([]string{"/db/cert"}))
+		})
+
+		It("returns an error when a credential can not be found", func() {
+			_, err := ExpandJSON([]byte(`{"password": "((/missing))"}`), lookup)
+
+			Expect(err).To(MatchError("Finding variable '/missing': response did not contain any credentials"))
+		})
+	})
+
+	Describe("(ch *CredHub) Expand()", func() {
+		It("substitutes the current value of each referenced credential", func() {
+			dummy := &DummyAuth{Response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{"data":[{
+						"id": "some-id",
+						"name": "/example-password",
+						"type": "password",
+						"value": "some-password",
+						"version_created_at": "2017-01-05T01:01:01Z"
+					}]}`)),
+			}}
+
+			ch, _ := New("https://example.com", Auth(dummy.Builder()))
+
+			result, err := ch.Expand("password=((/example-password))")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("password=some-password"))
+			Expect(dummy.Request.URL.Query().Get("name")).To(Equal("/example-password"))
+			Expect(dummy.Request.URL.Query().Get("current")).To(Equal("true"))
+		})
+	})
+})
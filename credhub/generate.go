@@ -70,6 +70,8 @@ func (ch *CredHub) generateCredential(name, credType string, gen interface{}, ov
 		requestBody["value"] = map[string]string{"username": user.Username}
 	}
 
+	defer ch.invalidateCache(name)
+
 	resp, err := ch.Request(http.MethodPost, "/api/v1/data", nil, requestBody, true)
 
 	if err != nil {
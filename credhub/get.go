@@ -3,6 +3,7 @@ package credhub
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -38,6 +39,13 @@ func (ch *CredHub) GetLatestVersion(name string) (credentials.Credential, error)
 	return cred, err
 }
 
+// GetLatestMetadata returns the ID, name, type and creation time of the current credential version for a given credential name. CredHub has no request for the metadata alone, so the value is still sent by the server, but it is not decoded. The read cache and the error types are the same as for GetLatestVersion.
+func (ch *CredHub) GetLatestMetadata(name string) (credentials.Metadata, error) {
+	var metadata credentials.Metadata
+	err := ch.getCurrentCredential(name, &metadata)
+	return metadata, err
+}
+
 // GetNVersions returns the N most recent credential versions for a given credential name. The returned credentials will be encoded as a list of maps and may be of any type.
 func (ch *CredHub) GetNVersions(name string, numberOfVersions int) ([]credentials.Credential, error) {
 	creds, err := ch.getNVersionsOfCredential(name, numberOfVersions)
@@ -106,14 +114,47 @@ func (ch *CredHub) getCurrentCredential(name string, cred interface{}) error {
 	query.Set("current", "true")
 	query.Set("name", name)
 
-	return ch.makeCredentialGetRequest(query, cred)
+	if !ch.cacheEnabled() {
+		return ch.makeCredentialGetRequest(query, cred)
+	}
+
+	rawMessage, err := ch.cache.get(nameCacheKey(name), func() (json.RawMessage, error) {
+		return ch.makeRawCredentialGetRequest(query)
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(rawMessage, cred)
+}
+
+// getUncachedCurrentCredential gets the current version of a credential from
+// the server, even if a read cache is configured
+func (ch *CredHub) getUncachedCurrentCredential(name string) (credentials.Credential, error) {
+	var cred credentials.Credential
+
+	query := url.Values{}
+	query.Set("current", "true")
+	query.Set("name", name)
+
+	err := ch.makeCredentialGetRequest(query, &cred)
+	return cred, err
 }
 
 func (ch *CredHub) makeCredentialGetRequest(query url.Values, cred interface{}) error {
+	rawMessage, err := ch.makeRawCredentialGetRequest(query)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(rawMessage, cred)
+}
+
+func (ch *CredHub) makeRawCredentialGetRequest(query url.Values) (json.RawMessage, error) {
 	resp, err := ch.Request(http.MethodGet, "/api/v1/data", query, nil, true)
 
 	if err != nil {
-		return addErrorDescription(err, " making an http request")
+		return nil, addErrorDescription(err, " making an http request")
 	}
 
 	defer resp.Body.Close()
@@ -123,22 +164,37 @@ func (ch *CredHub) makeCredentialGetRequest(query url.Values, cred interface{})
 	response := make(map[string][]json.RawMessage)
 
 	if err := dec.Decode(&response); err != nil {
-		return addErrorDescription(err, " while decoding http response")
+		return nil, addErrorDescription(err, " while decoding http response")
 	}
 
 	var ok bool
 	var data []json.RawMessage
 
 	if data, ok = response["data"]; !ok || len(data) == 0 {
-		return errors.New("response did not contain any credentials")
+		return nil, errors.New("response did not contain any credentials")
 	}
 
-	rawMessage := data[0]
+	return data[0], nil
+}
+
+func (ch *CredHub) makeCredentialGetByIdRequest(id string, cred *credentials.Credential) error {
+	if !ch.cacheEnabled() {
+		return ch.decodeCredentialGetByIdRequest(id, cred)
+	}
+
+	rawMessage, err := ch.cache.get(idCacheKey(id), func() (json.RawMessage, error) {
+		var rawMessage json.RawMessage
+		err := ch.decodeCredentialGetByIdRequest(id, &rawMessage)
+		return rawMessage, err
+	})
+	if err != nil {
+		return err
+	}
 
 	return json.Unmarshal(rawMessage, cred)
 }
 
-func (ch *CredHub) makeCredentialGetByIdRequest(id string, cred *credentials.Credential) error {
+func (ch *CredHub) decodeCredentialGetByIdRequest(id string, cred interface{}) error {
 	resp, err := ch.Request(http.MethodGet, "/api/v1/data/"+id, nil, nil, true)
 
 	if err != nil {
@@ -193,5 +249,5 @@ func addErrorDescription(err error, message string) error {
 	if strings.HasSuffix(err.Error(), message) {
 		return err
 	}
-	return errors.New(err.Error() + message)
+	return fmt.Errorf("%w%s", err, message)
 }
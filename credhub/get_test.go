@@ -88,6 +88,44 @@ var _ = Describe("Get", func() {
 		})
 	})
 
+	Describe("GetLatestMetadata()", func() {
+		It("returns the metadata of the current version without the value", func() {
+			dummyAuth := &DummyAuth{Response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{"data": [{
+					"id": "some-id",
+					"name": "/example-password",
+					"type": "password",
+					"value": "some-password",
+					"version_created_at": "2017-01-05T01:01:01Z"
+				}]}`)),
+			}}
+
+			ch, _ := New("https://example.com", Auth(dummyAuth.Builder()))
+
+			metadata, err := ch.GetLatestMetadata("/example-password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dummyAuth.Request.URL.String()).To(Equal("https://example.com/api/v1/data?current=true&name=%2Fexample-password"))
+			Expect(metadata.Id).To(Equal("some-id"))
+			Expect(metadata.Name).To(Equal("/example-password"))
+			Expect(metadata.Type).To(Equal("password"))
+			Expect(metadata.VersionCreatedAt).To(Equal("2017-01-05T01:01:01Z"))
+		})
+
+		It("returns the error of the server", func() {
+			dummyAuth := &DummyAuth{Response: &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error": "The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`)),
+			}}
+
+			ch, _ := New("https://example.com", Auth(dummyAuth.Builder()))
+
+			_, err := ch.GetLatestMetadata("/example-password")
+			var notFound *NotFoundError
+			Expect(errors.As(err, &notFound)).To(BeTrue())
+		})
+	})
+
 	Describe("GetById()", func() {
 		It("requests the credential by id", func() {
 			dummyAuth := &DummyAuth{Response: &http.Response{
@@ -841,7 +879,9 @@ var _ = Describe("Get", func() {
 
 			err = performAction(ch)
 
-			Expect(err).To(Equal(networkError))
+			var netErr *NetworkError
+			Expect(errors.As(err, &netErr)).To(BeTrue())
+			Expect(netErr.Err).To(Equal(networkError))
 		},
 
 		Entry("GetNVersions", func(ch *CredHub) error {
@@ -0,0 +1,94 @@
+package credhub
+
+import (
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials/generate"
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials/values"
+	"code.cloudfoundry.org/credhub-cli/credhub/permissions"
+)
+
+//go:generate counterfeiter . Client
+
+// Getter gets credentials by name or ID
+type Getter interface {
+	GetById(id string) (credentials.Credential, error)
+	GetAllVersions(name string) ([]credentials.Credential, error)
+	GetLatestVersion(name string) (credentials.Credential, error)
+	GetLatestMetadata(name string) (credentials.Metadata, error)
+	GetNVersions(name string, numberOfVersions int) ([]credentials.Credential, error)
+	GetLatestValue(name string) (credentials.Value, error)
+	GetLatestJSON(name string) (credentials.JSON, error)
+	GetLatestPassword(name string) (credentials.Password, error)
+	GetLatestUser(name string) (credentials.User, error)
+	GetLatestCertificate(name string) (credentials.Certificate, error)
+	GetLatestRSA(name string) (credentials.RSA, error)
+	GetLatestSSH(name string) (credentials.SSH, error)
+}
+
+// Setter sets credentials with user-provided values
+type Setter interface {
+	SetValue(name string, value values.Value) (credentials.Value, error)
+	SetJSON(name string, value values.JSON) (credentials.JSON, error)
+	SetPassword(name string, value values.Password) (credentials.Password, error)
+	SetUser(name string, value values.User) (credentials.User, error)
+	SetCertificate(name string, value values.Certificate) (credentials.Certificate, error)
+	SetRSA(name string, value values.RSA) (credentials.RSA, error)
+	SetSSH(name string, value values.SSH) (credentials.SSH, error)
+	SetCredential(name, credType string, value interface{}) (credentials.Credential, error)
+	SetCredentialIfVersion(name, credType string, value interface{}, expectedVersionID string) (credentials.Credential, error)
+	UpdateCredential(name string, maxAttempts int, modify func(current credentials.Credential) (interface{}, error)) (credentials.Credential, error)
+	MergeJSON(name string, patch values.JSON) (credentials.JSON, error)
+	PatchJSON(name string, operations []JSONPatchOperation) (credentials.JSON, error)
+}
+
+// Generator generates and regenerates credentials
+type Generator interface {
+	GeneratePassword(name string, gen generate.Password, overwrite Mode) (credentials.Password, error)
+	GenerateUser(name string, gen generate.User, overwrite Mode) (credentials.User, error)
+	GenerateCertificate(name string, gen generate.Certificate, overwrite Mode) (credentials.Certificate, error)
+	GenerateRSA(name string, gen generate.RSA, overwrite Mode) (credentials.RSA, error)
+	GenerateSSH(name string, gen generate.SSH, overwrite Mode) (credentials.SSH, error)
+	GenerateCredential(name, credType string, gen interface{}, overwrite Mode) (credentials.Credential, error)
+	Regenerate(name string) (credentials.Credential, error)
+	BulkRegenerate(signedBy string) (credentials.BulkRegenerateResults, error)
+}
+
+// Deleter deletes credentials
+type Deleter interface {
+	Delete(name string) error
+}
+
+// Finder finds credentials by path or partial name
+type Finder interface {
+	FindByPath(path string) (credentials.FindResults, error)
+	FindByPartialName(nameLike string) (credentials.FindResults, error)
+}
+
+// PermissionManager gets and adds permissions on credentials
+type PermissionManager interface {
+	GetPermissions(name string) ([]permissions.V1_Permission, error)
+	GetPermission(uuid string) (*permissions.Permission, error)
+	AddPermission(path string, actor string, ops []string) (*permissions.Permission, error)
+}
+
+// Interpolator fills credential references with their values
+type Interpolator interface {
+	InterpolateString(vcapServicesBody string) (string, error)
+	Expand(text string) (string, error)
+}
+
+// Client provides all operations on credentials.
+//
+// Depend on Client, or on one of the interfaces it is composed of, to replace
+// *CredHub with a fake in tests, e.g. credhubfakes.FakeClient.
+type Client interface {
+	Getter
+	Setter
+	Generator
+	Deleter
+	Finder
+	PermissionManager
+	Interpolator
+}
+
+var _ Client = (*CredHub)(nil)
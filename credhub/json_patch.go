@@ -0,0 +1,347 @@
+package credhub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials/values"
+)
+
+// JSONPatchOperation is an operation of a JSON patch (RFC 6902), e.g.
+//
+//	JSONPatchOperation{Op: "replace", Path: "/db/password", Value: "new-password"}
+//
+// Supported operations are add, remove, replace, move, copy and test.
+type JSONPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value"`
+}
+
+// MergeJSON applies a JSON merge patch (RFC 7386) to the current value of a
+// JSON credential and sets the result as a new version. Objects in the patch
+// are merged recursively and null removes a field.
+//
+// The current version is checked again before the new version is set. If it
+// was changed in between, a VersionConflictError is returned and nothing is set.
+func (ch *CredHub) MergeJSON(name string, patch values.JSON) (credentials.JSON, error) {
+	return ch.updateJSON(name, func(value interface{}) (interface{}, error) {
+		p, err := toJSONValue(patch)
+		if err != nil {
+			return nil, err
+		}
+		return mergePatch(value, p), nil
+	})
+}
+
+// PatchJSON applies JSON patch operations (RFC 6902) to the current value of
+// a JSON credential and sets the result as a new version. If any operation
+// fails, including a failed test operation, nothing is set.
+//
+// The current version is checked again before the new version is set. If it
+// was changed in between, a VersionConflictError is returned and nothing is set.
+func (ch *CredHub) PatchJSON(name string, operations []JSONPatchOperation) (credentials.JSON, error) {
+	return ch.updateJSON(name, func(value interface{}) (interface{}, error) {
+		return applyPatch(value, operations)
+	})
+}
+
+func (ch *CredHub) updateJSON(name string, update func(interface{}) (interface{}, error)) (credentials.JSON, error) {
+	var cred credentials.JSON
+
+	current, err := ch.getUncachedCurrentCredential(name)
+	if err != nil {
+		return cred, err
+	}
+	if current.Type != "json" {
+		return cred, fmt.Errorf("the credential '%s' is of type '%s', not 'json'", name, current.Type)
+	}
+
+	value, err := toJSONValue(current.Value)
+	if err != nil {
+		return cred, err
+	}
+
+	updated, err := update(value)
+	if err != nil {
+		return cred, err
+	}
+
+	object, ok := updated.(map[string]interface{})
+	if !ok {
+		return cred, errors.New("the updated value of a JSON credential must be an object")
+	}
+
+	err = ch.setCredentialIfVersion(name, "json", values.JSON(object), current.Id, &cred)
+	return cred, err
+}
+
+// toJSONValue returns a copy of v as the maps, slices and scalars produced by
+// decoding its JSON representation
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for k, v := range patchObject {
+		if v == nil {
+			delete(targetObject, k)
+		} else {
+			targetObject[k] = mergePatch(targetObject[k], v)
+		}
+	}
+
+	return targetObject
+}
+
+func applyPatch(doc interface{}, operations []JSONPatchOperation) (interface{}, error) {
+	for _, op := range operations {
+		path, err := parsePointer(op.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			value, err := toJSONValue(op.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			switch op.Op {
+			case "add":
+				doc, err = patchAdd(doc, path, value)
+			case "replace":
+				doc, err = patchReplace(doc, path, value)
+			case "test":
+				err = patchTest(doc, path, value, op.Path)
+			}
+			if err != nil {
+				return nil, err
+			}
+		case "remove":
+			if doc, err = patchRemove(doc, path); err != nil {
+				return nil, err
+			}
+		case "move", "copy":
+			from, err := parsePointer(op.From)
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := pointerGet(doc, from)
+			if err != nil {
+				return nil, err
+			}
+
+			if op.Op == "move" {
+				if strings.HasPrefix(op.Path, op.From+"/") {
+					return nil, fmt.Errorf("cannot move '%s' into one of its children", op.From)
+				}
+				if doc, err = patchRemove(doc, from); err != nil {
+					return nil, err
+				}
+			} else if value, err = toJSONValue(value); err != nil {
+				return nil, err
+			}
+
+			if doc, err = patchAdd(doc, path, value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported JSON patch operation '%s'", op.Op)
+		}
+	}
+
+	return doc, nil
+}
+
+// parsePointer splits a JSON pointer (RFC 6901) into its unescaped tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer '%s'", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+
+	return tokens, nil
+}
+
+func arrayIndex(token string, length int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i >= length || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index '%s'", token)
+	}
+	return i, nil
+}
+
+func pointerGet(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch container := doc.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("path '%s' does not exist", token)
+			}
+			doc = value
+		case []interface{}:
+			i, err := arrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+			doc = container[i]
+		default:
+			return nil, fmt.Errorf("path '%s' does not exist", token)
+		}
+	}
+
+	return doc, nil
+}
+
+// updateParent calls update with the container of the last token of path and
+// that token, and returns doc with the container replaced by the result
+func updateParent(doc interface{}, path []string, update func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return update(doc, path[0])
+	}
+
+	child, err := pointerGet(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+
+	child, err = updateParent(child, path[1:], update)
+	if err != nil {
+		return nil, err
+	}
+
+	if array, ok := doc.([]interface{}); ok {
+		i, _ := arrayIndex(path[0], len(array))
+		array[i] = child
+		return array, nil
+	}
+
+	doc.(map[string]interface{})[path[0]] = child
+	return doc, nil
+}
+
+func patchAdd(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateParent(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			if token == "-" {
+				return append(container, value), nil
+			}
+			i, err := arrayIndex(token, len(container)+1)
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[i+1:], container[i:])
+			container[i] = value
+			return container, nil
+		default:
+			return nil, fmt.Errorf("path '%s' does not exist", token)
+		}
+	})
+}
+
+func patchRemove(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cannot remove the whole value")
+	}
+
+	return updateParent(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := container[token]; !ok {
+				return nil, fmt.Errorf("path '%s' does not exist", token)
+			}
+			delete(container, token)
+			return container, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+			return append(container[:i], container[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("path '%s' does not exist", token)
+		}
+	})
+}
+
+func patchReplace(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateParent(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := container[token]; !ok {
+				return nil, fmt.Errorf("path '%s' does not exist", token)
+			}
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+			container[i] = value
+			return container, nil
+		default:
+			return nil, fmt.Errorf("path '%s' does not exist", token)
+		}
+	})
+}
+
+func patchTest(doc interface{}, path []string, value interface{}, pointer string) error {
+	current, err := pointerGet(doc, path)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(current, value) {
+		return fmt.Errorf("test failed: the value at '%s' does not match", pointer)
+	}
+
+	return nil
+}
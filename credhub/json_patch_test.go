@@ -0,0 +1,144 @@
+package credhub_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	. "code.cloudfoundry.org/credhub-cli/credhub"
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials/values"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("JSON updates", func() {
+	var server *ghttp.Server
+
+	currentJSON := func(id, value string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/data", "current=true&name=/example-json"),
+			ghttp.RespondWith(http.StatusOK, `{"data":[{"id":"`+id+`","name":"/example-json","type":"json","value":`+value+`}]}`),
+		)
+	}
+
+	expectSet := func(value string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", "/api/v1/data"),
+			ghttp.VerifyJSON(`{"name":"/example-json","type":"json","value":`+value+`}`),
+			ghttp.RespondWith(http.StatusOK, `{"id":"id-2","name":"/example-json","type":"json","value":`+value+`}`),
+		)
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("MergeJSON()", func() {
+		It("merges objects recursively and removes fields set to null", func() {
+			current := `{"a":{"b":1,"c":2},"d":"remove-me","e":[1,2]}`
+			server.AppendHandlers(
+				currentJSON("id-1", current),
+				currentJSON("id-1", current),
+				expectSet(`{"a":{"b":3,"c":2},"e":["x"],"f":true}`),
+			)
+
+			ch, _ := New(server.URL(), ServerVersion("2.0.0"))
+			cred, err := ch.MergeJSON("/example-json", values.JSON{
+				"a": map[string]interface{}{"b": 3},
+				"d": nil,
+				"e": []string{"x"},
+				"f": true,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cred.Id).To(Equal("id-2"))
+		})
+
+		It("returns a ConflictError without setting anything when the credential changed in between", func() {
+			server.AppendHandlers(
+				currentJSON("id-1", `{"a":1}`),
+				currentJSON("id-other", `{"a":2}`),
+			)
+
+			ch, _ := New(server.URL(), ServerVersion("2.0.0"))
+			_, err := ch.MergeJSON("/example-json", values.JSON{"b": 1})
+
+			var conflict *ConflictError
+			Expect(errors.As(err, &conflict)).To(BeTrue())
+			Expect(err).To(MatchError("The credential '/example-json' was changed by another client: expected version 'id-1' but the current version is 'id-other'. Please retry your request."))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("does not read the current version from the cache", func() {
+			server.AppendHandlers(
+				currentJSON("id-1", `{"a":1}`),
+				currentJSON("id-2", `{"a":2}`),
+				currentJSON("id-2", `{"a":2}`),
+				expectSet(`{"a":2,"b":1}`),
+			)
+
+			ch, _ := New(server.URL(), ServerVersion("2.0.0"), Cache(time.Minute, 10))
+			ch.GetLatestVersion("/example-json")
+
+			_, err := ch.MergeJSON("/example-json", values.JSON{"b": 1})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("refuses to update credentials of other types", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{"data":[{"id":"id-1","name":"/example-json","type":"password","value":"secret"}]}`))
+
+			ch, _ := New(server.URL(), ServerVersion("2.0.0"))
+			_, err := ch.MergeJSON("/example-json", values.JSON{"b": 1})
+
+			Expect(err).To(MatchError("the credential '/example-json' is of type 'password', not 'json'"))
+		})
+	})
+
+	Describe("PatchJSON()", func() {
+		It("applies the operations in order", func() {
+			current := `{"a":{"b":1},"list":[1,2,3],"a~b":"escaped","old":"moved"}`
+			server.AppendHandlers(
+				currentJSON("id-1", current),
+				currentJSON("id-1", current),
+				expectSet(`{"a":{"b":1,"c":[1,3,4]},"list":[9,3,4],"new":"moved"}`),
+			)
+
+			ch, _ := New(server.URL(), ServerVersion("2.0.0"))
+			_, err := ch.PatchJSON("/example-json", []JSONPatchOperation{
+				{Op: "test", Path: "/a/b", Value: 1},
+				{Op: "remove", Path: "/list/1"},
+				{Op: "add", Path: "/list/-", Value: 4},
+				{Op: "remove", Path: "/a~0b"},
+				{Op: "move", From: "/old", Path: "/new"},
+				{Op: "copy", From: "/list", Path: "/a/c"},
+				{Op: "replace", Path: "/list/0", Value: 9},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("does not set anything when an operation fails",
+			func(op JSONPatchOperation, message string) {
+				server.AppendHandlers(currentJSON("id-1", `{"a":{"b":1},"list":[1]}`))
+
+				ch, _ := New(server.URL(), ServerVersion("2.0.0"))
+				_, err := ch.PatchJSON("/example-json", []JSONPatchOperation{op})
+
+				Expect(err).To(MatchError(message))
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			},
+			Entry("failed test", JSONPatchOperation{Op: "test", Path: "/a/b", Value: 2}, "test failed: the value at '/a/b' does not match"),
+			Entry("missing path", JSONPatchOperation{Op: "replace", Path: "/missing", Value: 2}, "path 'missing' does not exist"),
+			Entry("invalid index", JSONPatchOperation{Op: "remove", Path: "/list/5"}, "invalid array index '5'"),
+			Entry("unknown operation", JSONPatchOperation{Op: "increment", Path: "/a/b"}, "unsupported JSON patch operation 'increment'"),
+			Entry("replacing the object", JSONPatchOperation{Op: "replace", Path: "", Value: "string"}, "the updated value of a JSON credential must be an object"),
+		)
+	})
+})
@@ -0,0 +1,203 @@
+package credhub
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/socks5-proxy"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshDialTimeout is the time allowed for connecting to the SSH server and
+// completing the handshake
+const sshDialTimeout = 30 * time.Second
+
+// knownHostsProxyDialer opens SSH tunnels to a server whose host key is pinned
+// in a known_hosts file
+type knownHostsProxyDialer struct {
+	hostKeys []ssh.PublicKey
+}
+
+// newKnownHostsProxyDialer reads the keys of address from the known_hosts file
+// at knownHostsPath. Revoked keys and certificate authorities are ignored.
+func newKnownHostsProxyDialer(knownHostsPath, address string) (*knownHostsProxyDialer, error) {
+	hostKeys, err := readKnownHostKeys(knownHostsPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hostKeys) == 0 {
+		return nil, fmt.Errorf("no host key for '%s' was found in '%s'", knownHostsAddress(address), knownHostsPath)
+	}
+
+	return &knownHostsProxyDialer{hostKeys: hostKeys}, nil
+}
+
+// defaultKnownHostsProxyDialer returns a dialer for the keys of address in
+// ~/.ssh/known_hosts. It returns an error naming the host when the file does not
+// exist or has no keys for address, as the host key could not be checked.
+func defaultKnownHostsProxyDialer(address string) (*knownHostsProxyDialer, error) {
+	var hostKeys []ssh.PublicKey
+	if home, err := os.UserHomeDir(); err == nil {
+		hostKeys, _ = readKnownHostKeys(filepath.Join(home, ".ssh", "known_hosts"), address)
+	}
+
+	if len(hostKeys) == 0 {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			host, port = address, "22"
+		}
+		return nil, fmt.Errorf("the host key of '%s' is not known. Pin it in a file given with the 'known-hosts' parameter of the proxy, e.g. one written with 'ssh-keyscan -p %s %s', or add it to ~/.ssh/known_hosts", knownHostsAddress(address), port, host)
+	}
+
+	return &knownHostsProxyDialer{hostKeys: hostKeys}, nil
+}
+
+// readKnownHostKeys returns the keys of address in the known_hosts file at
+// knownHostsPath
+func readKnownHostKeys(knownHostsPath, address string) ([]ssh.PublicKey, error) {
+	data, err := ioutil.ReadFile(knownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the known hosts: %s", err)
+	}
+
+	host := knownHostsAddress(address)
+
+	var hostKeys []ssh.PublicKey
+	for len(data) > 0 {
+		var (
+			marker string
+			hosts  []string
+			key    ssh.PublicKey
+		)
+		marker, hosts, key, _, data, err = ssh.ParseKnownHosts(data)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the known hosts: %s", err)
+		}
+		if marker == "" && knownHostsMatch(hosts, host) {
+			hostKeys = append(hostKeys, key)
+		}
+	}
+
+	return hostKeys, nil
+}
+
+func (d *knownHostsProxyDialer) Dialer(username, key, address string) (proxy.DialFunc, error) {
+	if username == "" {
+		username = "jumpbox"
+	}
+
+	signer, err := ssh.ParsePrivateKey([]byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %s", err)
+	}
+
+	var algorithms []string
+	for _, hostKey := range d.hostKeys {
+		algorithms = append(algorithms, hostKey.Type())
+	}
+
+	conn, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:              username,
+		Auth:              []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback:   d.checkHostKey,
+		HostKeyAlgorithms: algorithms,
+		Timeout:           sshDialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ssh dial: %s", err)
+	}
+
+	return conn.Dial, nil
+}
+
+func (d *knownHostsProxyDialer) checkHostKey(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	for _, hostKey := range d.hostKeys {
+		if bytes.Equal(hostKey.Marshal(), key.Marshal()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the host key of '%s' does not match the known hosts", hostname)
+}
+
+// knownHostsAddress returns address as written in known_hosts files, where
+// the port is only included when it is not 22
+func knownHostsAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	if port == "22" {
+		return host
+	}
+	return "[" + host + "]:" + port
+}
+
+// knownHostsMatch is true when host matches one of the patterns of a
+// known_hosts line, which may be hashed or contain wildcards
+func knownHostsMatch(patterns []string, host string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+
+		var ok bool
+		if strings.HasPrefix(pattern, "|1|") {
+			ok = hashedHostMatch(pattern, host)
+		} else {
+			ok = wildcardMatch(pattern, host)
+		}
+
+		if ok && negated {
+			return false
+		}
+		matched = matched || ok
+	}
+	return matched
+}
+
+// wildcardMatch is true when host matches pattern, in which '*' matches any
+// characters and '?' matches one character
+func wildcardMatch(pattern, host string) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.Replace(expr, `\*`, ".*", -1)
+	expr = strings.Replace(expr, `\?`, ".", -1)
+	matched, _ := regexp.MatchString("^"+expr+"$", host)
+	return matched
+}
+
+// hashedHostMatch is true when pattern is '|1|salt|hash' with the hash being
+// the HMAC-SHA1 of host with the salt, both base64 encoded
+func hashedHostMatch(pattern, host string) bool {
+	parts := strings.Split(strings.TrimPrefix(pattern, "|1|"), "|")
+	if len(parts) != 2 {
+		return false
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return false
+	}
+	hash, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha1.New, salt)
+	mac.Write([]byte(host))
+	return hmac.Equal(mac.Sum(nil), hash)
+}
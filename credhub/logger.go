@@ -0,0 +1,249 @@
+package credhub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel is the severity of a log entry
+type LogLevel int
+
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "debug"
+	case LogInfo:
+		return "info"
+	case LogWarn:
+		return "warn"
+	default:
+		return "error"
+	}
+}
+
+// LogFields are the structured fields of a log entry, e.g. method, path, status and duration
+type LogFields map[string]interface{}
+
+// LeveledLogger receives log entries for the requests sent to the CredHub server.
+//
+// Responses are logged at LogDebug level, or LogWarn for error responses, with
+// their request and response bodies. Tokens, credential values, credentials
+// blocks and private keys are redacted before entries are passed to the logger.
+// Bodies are only logged for endpoints whose secret fields are known. For other
+// endpoints, e.g. interpolate or paths requested with Request, only their size
+// is logged.
+//
+// Form requests sent with the client returned by Client, i.e. the token requests
+// to the UAA or OIDC provider, are logged at LogInfo level, or LogWarn for error
+// responses, with passwords, client secrets and tokens redacted.
+type LeveledLogger interface {
+	Log(level LogLevel, message string, fields LogFields)
+}
+
+// NewTextLogger returns a logger that writes entries with at least the given level
+// to w, one line per entry, e.g.
+//
+//	level=debug msg="response received" duration=12ms method=GET path=/api/v1/data status=200
+func NewTextLogger(w io.Writer, minLevel LogLevel) LeveledLogger {
+	return &textLogger{writer: w, minLevel: minLevel}
+}
+
+type textLogger struct {
+	mu       sync.Mutex
+	writer   io.Writer
+	minLevel LogLevel
+}
+
+func (l *textLogger) Log(level LogLevel, message string, fields LogFields) {
+	if level < l.minLevel {
+		return
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	line := &bytes.Buffer{}
+	fmt.Fprintf(line, "level=%s msg=%q", level, message)
+	for _, k := range keys {
+		fmt.Fprintf(line, " %s=%s", k, formatLogValue(fields[k]))
+	}
+	line.WriteString("\n")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.writer.Write(line.Bytes())
+}
+
+func formatLogValue(value interface{}) string {
+	s := fmt.Sprint(value)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+// logger returns the logger provided with the Logger option. Without it, and
+// when CREDHUB_DEBUG is set to true, debug entries are written to stderr.
+func (ch *CredHub) logger() LeveledLogger {
+	if ch.requestLogger != nil {
+		return ch.requestLogger
+	}
+	if os.Getenv("CREDHUB_DEBUG") == "true" {
+		return NewTextLogger(os.Stderr, LogDebug)
+	}
+	return nil
+}
+
+const redacted = "<redacted>"
+
+// redactedFields are JSON fields whose values are never logged
+var redactedFields = map[string]bool{
+	"value":         true,
+	"password":      true,
+	"private_key":   true,
+	"password_hash": true,
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+	"token":         true,
+	"credentials":   true,
+}
+
+// tokenLogTransport logs the form requests sent through the next transport, i.e.
+// the token requests to the UAA or OIDC provider
+type tokenLogTransport struct {
+	logger LeveledLogger
+	next   http.RoundTripper
+}
+
+func (t *tokenLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return t.next.RoundTrip(req)
+	}
+
+	var requestBody []byte
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			requestBody, _ = ioutil.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	fields := requestLogFields(req, time.Since(start))
+	fields["request_body"] = redactForm(requestBody)
+	logResponse(t.logger, LogInfo, fields, resp, err, redactBody)
+
+	return resp, err
+}
+
+// redactForm returns a form encoded body for logging, with secret fields replaced
+func redactForm(body []byte) string {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of invalid form content>", len(body))
+	}
+
+	for k := range form {
+		if redactedFields[strings.ToLower(k)] {
+			form[k] = []string{redacted}
+		}
+	}
+	return form.Encode()
+}
+
+// loggedBodyPaths are the endpoints whose bodies are logged in redacted form
+var loggedBodyPaths = []string{
+	"/api/v1/data",
+	"/api/v1/bulk-regenerate",
+	"/api/v1/certificates",
+	"/api/v1/permissions",
+	"/api/v2/permissions",
+	"/info",
+	"/version",
+	"/health",
+}
+
+// logBody returns a body of a request to path for logging
+func logBody(path string, body []byte) string {
+	for _, p := range loggedBodyPaths {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return redactBody(body)
+		}
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("<%d bytes omitted>", len(body))
+}
+
+// redactBody returns a JSON body for logging, with secret fields replaced
+func redactBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return fmt.Sprintf("<%d bytes of non-JSON content>", len(body))
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(redactValue(data)); err != nil {
+		return redacted
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func redactValue(data interface{}) interface{} {
+	switch typed := data.(type) {
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for k, v := range typed {
+			if redactedFields[strings.ToLower(k)] {
+				result[k] = redacted
+			} else {
+				result[k] = redactValue(v)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(typed))
+		for i, v := range typed {
+			result[i] = redactValue(v)
+		}
+		return result
+	case string:
+		if strings.Contains(typed, "PRIVATE KEY") {
+			return redacted
+		}
+		return typed
+	default:
+		return typed
+	}
+}
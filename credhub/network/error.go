@@ -0,0 +1,20 @@
+// Errors for requests that could not reach a server
+package network
+
+// Error is returned when a request could not be sent to the server or no
+// response was received
+type Error struct {
+	// Method and Path of the request that failed. Empty if the request is unknown.
+	Method string
+	Path   string
+
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -31,6 +31,13 @@ func New(target string, options ...Option) (*CredHub, error) {
 		}
 	}
 
+	if credhub.httpClient == nil {
+		credhub.proxyDial, err = credhub.proxyDialer()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	credhub.Auth, err = credhub.authBuilder(credhub)
 	if err != nil {
 		return nil, err
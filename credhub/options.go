@@ -4,8 +4,10 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"net/http"
 	"net/url"
 	"runtime"
+	"time"
 
 	"code.cloudfoundry.org/credhub-cli/credhub/auth"
 )
@@ -88,6 +90,103 @@ func ClientCert(certificate, key string) Option {
 	}
 }
 
+// Dialer specifies the function used to open connections to the CredHub server,
+// e.g. to connect through a Unix socket. The proxy setting still applies.
+func Dialer(dial DialFunc) Option {
+	return func(c *CredHub) error {
+		c.dialer = dial
+		return nil
+	}
+}
+
+// Proxy specifies the proxy for connections to the CredHub server and the auth
+// server, in one of the formats described for ProxyDialFunc. Without this
+// option, or when proxyURL is empty, the proxy returned by ProxyFromEnvironment
+// is used. New returns an error when the proxy is not valid.
+func Proxy(proxyURL string) Option {
+	return func(c *CredHub) error {
+		c.proxyURL = proxyURL
+		return nil
+	}
+}
+
+// HTTPClient specifies the http.Client used for requests to the CredHub server
+// and the auth server. The CaCerts, SkipTLSValidation, ClientCert, Dialer and
+// Proxy options have no effect when a client is provided. The client is copied, so
+// the provided client is not modified by the Middleware option.
+func HTTPClient(client *http.Client) Option {
+	return func(c *CredHub) error {
+		if client == nil {
+			return errors.New("http client must not be nil")
+		}
+		c.httpClient = client
+		return nil
+	}
+}
+
+// Middleware wraps the transport of the http.Client used for requests to the
+// CredHub server and the auth server, e.g. to record metrics, add tracing spans
+// or inject headers. When provided several times, the first middleware is the
+// outermost and sees each request first.
+func Middleware(middleware ...func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *CredHub) error {
+		c.middleware = append(c.middleware, middleware...)
+		return nil
+	}
+}
+
+// Logger specifies the logger that receives an entry for each request sent to the
+// CredHub server. Secrets are redacted from the entries. Without this option,
+// entries are written to stderr when the CREDHUB_DEBUG environment variable is true.
+func Logger(logger LeveledLogger) Option {
+	return func(c *CredHub) error {
+		c.requestLogger = logger
+		return nil
+	}
+}
+
+// Cache caches credentials read by name or ID for the given time, keeping at most
+// maxEntries credentials (or any number when maxEntries is 0).
+//
+// Cached credentials are removed when they are changed through the same client with
+// SetCredential, GenerateCredential, Regenerate, BulkRegenerate or Delete, and the
+// typed variants of these methods. Changes made by other clients are seen once the
+// cached credential expires. Use CacheStats to get hit and miss statistics.
+func Cache(ttl time.Duration, maxEntries int) Option {
+	return func(c *CredHub) error {
+		if ttl <= 0 {
+			return errors.New("cache ttl must be positive")
+		}
+		if maxEntries < 0 {
+			return errors.New("cache max entries must not be negative")
+		}
+		if c.cache == nil {
+			c.cache = newReadCache()
+		}
+		c.cache.ttl = ttl
+		c.cache.maxEntries = maxEntries
+		return nil
+	}
+}
+
+// CacheStaleWhileRevalidate serves cached credentials for up to maxStale after they
+// have expired, while they are refreshed in the background. When the CredHub server
+// cannot be reached, the expired credentials are served until maxStale has passed.
+//
+// It has no effect unless the Cache option is also provided.
+func CacheStaleWhileRevalidate(maxStale time.Duration) Option {
+	return func(c *CredHub) error {
+		if maxStale < 0 {
+			return errors.New("cache max stale time must not be negative")
+		}
+		if c.cache == nil {
+			c.cache = newReadCache()
+		}
+		c.cache.maxStale = maxStale
+		return nil
+	}
+}
+
 func ServerVersion(version string) Option {
 	return func(c *CredHub) error {
 		c.cachedServerVersion = version
@@ -19,6 +19,8 @@ func (ch *CredHub) Regenerate(name string) (credentials.Credential, error) {
 	requestBody["name"] = name
 	requestBody["regenerate"] = true
 
+	defer ch.invalidateCache(name)
+
 	resp, err := ch.Request(http.MethodPost, regenerateEndpoint, nil, requestBody, true)
 
 	if err != nil {
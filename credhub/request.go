@@ -3,13 +3,12 @@ package credhub
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
-	"os"
+	"time"
 )
 
 // Request sends an authenticated request to the CredHub server.
@@ -52,18 +51,23 @@ func (ch *CredHub) request(client requester, method string, pathStr string, quer
 
 	req.Header.Set("Content-Type", "application/json")
 
-	if os.Getenv("CREDHUB_DEBUG") == "true" {
-		dumpRequest(req)
-	}
-
+	start := time.Now()
 	resp, err := client.Do(req)
 
-	if os.Getenv("CREDHUB_DEBUG") == "true" {
-		dumpResponse(resp)
+	if logger := ch.logger(); logger != nil {
+		var requestBody []byte
+		if body != nil {
+			requestBody = jsonBody
+		}
+		logExchange(logger, req, requestBody, resp, err, time.Since(start))
 	}
 
 	if err != nil {
-		return resp, err
+		var authErr *AuthError
+		if errors.As(err, &authErr) {
+			return resp, err
+		}
+		return resp, &NetworkError{Method: method, Path: pathStr, Err: err}
 	}
 
 	if checkServerErr {
@@ -81,30 +85,65 @@ func (ch *CredHub) checkForServerError(resp *http.Response) error {
 		defer io.Copy(ioutil.Discard, resp.Body)
 		dec := json.NewDecoder(resp.Body)
 
-		respErr := &Error{}
+		respErr := &Error{StatusCode: resp.StatusCode}
+		if resp.Request != nil {
+			respErr.Method = resp.Request.Method
+			respErr.Path = resp.Request.URL.Path
+		}
 
 		if err := dec.Decode(respErr); err != nil {
 			return err
 		}
 
-		return respErr
+		return newStatusError(respErr)
 	}
 
 	return nil
 }
 
-func dumpRequest(req *http.Request) {
-	dump, err := httputil.DumpRequestOut(req, true)
-	if err != nil {
-		fmt.Println("[DEBUG] An error occurred during request dump.", err.Error())
+func logExchange(logger LeveledLogger, req *http.Request, requestBody []byte, resp *http.Response, err error, duration time.Duration) {
+	fields := requestLogFields(req, duration)
+	if requestBody != nil {
+		fields["request_body"] = logBody(req.URL.Path, requestBody)
+	}
+
+	logResponse(logger, LogDebug, fields, resp, err, func(body []byte) string {
+		return logBody(req.URL.Path, body)
+	})
+}
+
+func requestLogFields(req *http.Request, duration time.Duration) LogFields {
+	fields := LogFields{
+		"method":   req.Method,
+		"path":     req.URL.Path,
+		"duration": duration.String(),
 	}
-	fmt.Println("[DEBUG]", string(dump))
+	if req.URL.RawQuery != "" {
+		fields["query"] = req.URL.RawQuery
+	}
+	return fields
 }
 
-func dumpResponse(resp *http.Response) {
-	dump, err := httputil.DumpResponse(resp, true)
+// logResponse logs a response at the given level, or LogWarn for error
+// responses, with its body formatted by formatBody
+func logResponse(logger LeveledLogger, level LogLevel, fields LogFields, resp *http.Response, err error, formatBody func([]byte) string) {
 	if err != nil {
-		fmt.Println("[DEBUG] An error occurred during response dump.", err.Error())
+		fields["error"] = err.Error()
+		logger.Log(LogError, "request failed", fields)
+		return
+	}
+
+	fields["status"] = resp.StatusCode
+
+	body, readErr := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if readErr == nil {
+		fields["response_body"] = formatBody(body)
+	}
+
+	if resp.StatusCode >= 400 {
+		level = LogWarn
 	}
-	fmt.Println("[DEBUG]", string(dump))
+	logger.Log(level, "response received", fields)
 }
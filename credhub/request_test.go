@@ -9,7 +9,9 @@ import (
 	. "code.cloudfoundry.org/credhub-cli/credhub"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 )
 
 var _ = Describe("Request()", func() {
@@ -36,7 +38,7 @@ var _ = Describe("Request()", func() {
 		response, err := ch.Request("PATCH", "/api/v1/some-endpoint", nil, payload, true)
 
 		Expect(response).To(Equal(mockAuth.Response))
-		Expect(err).To(Equal(mockAuth.Error))
+		Expect(err).To(Equal(&NetworkError{Method: "PATCH", Path: "/api/v1/some-endpoint", Err: mockAuth.Error}))
 
 		Expect(mockAuth.Request.Method).To(Equal("PATCH"))
 		Expect(mockAuth.Request.URL.String()).To(Equal("http://example.com/api/v1/some-endpoint"))
@@ -47,6 +49,14 @@ var _ = Describe("Request()", func() {
 		Expect(body).To(MatchJSON(`{"some-field": 1, "other-field": "blah"}`))
 	})
 
+	It("does not wrap errors from the auth strategy in a NetworkError", func() {
+		mockAuth.Error = &AuthError{Err: errors.New("You are not currently authenticated. Please log in to continue.")}
+
+		_, err := ch.Request("GET", "/api/v1/data", nil, nil, true)
+
+		Expect(err).To(Equal(mockAuth.Error))
+	})
+
 	It("fails to send the request when the body cannot be marshalled to JSON", func() {
 		_, err := ch.Request("PATCH", "/api/v1/some-endpoint", nil, &NotMarshallable{}, true)
 		Expect(err).To(HaveOccurred())
@@ -72,6 +82,34 @@ var _ = Describe("Request()", func() {
 
 				Expect(err).To(MatchError("error occurred"))
 			})
+
+			DescribeTable("returns a typed error for the status code",
+				func(statusCode int, target interface{}) {
+					server := ghttp.NewServer()
+					defer server.Close()
+					server.AppendHandlers(ghttp.RespondWith(statusCode, `{"error":"error occurred"}`))
+
+					ch, _ := New(server.URL())
+					_, err := ch.Request("GET", "/api/v1/data", nil, nil, true)
+
+					Expect(errors.As(err, target)).To(BeTrue())
+
+					var serverErr *Error
+					Expect(errors.As(err, &serverErr)).To(BeTrue())
+					Expect(serverErr.Name).To(Equal("error occurred"))
+					Expect(serverErr.StatusCode).To(Equal(statusCode))
+					Expect(serverErr.Method).To(Equal("GET"))
+					Expect(serverErr.Path).To(Equal("/api/v1/data"))
+				},
+				Entry("not found", http.StatusNotFound, new(*NotFoundError)),
+				Entry("unauthorized", http.StatusUnauthorized, new(*UnauthorizedError)),
+				Entry("forbidden", http.StatusForbidden, new(*ForbiddenError)),
+				Entry("conflict", http.StatusConflict, new(*ConflictError)),
+				Entry("precondition failed", http.StatusPreconditionFailed, new(*ConflictError)),
+				Entry("bad request", http.StatusBadRequest, new(*ValidationError)),
+				Entry("unprocessable entity", http.StatusUnprocessableEntity, new(*ValidationError)),
+				Entry("internal server error", http.StatusInternalServerError, new(*Error)),
+			)
 		})
 
 		Context("when checkServerError is false", func() {
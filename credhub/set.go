@@ -75,6 +75,33 @@ func (ch *CredHub) SetCredential(name, credType string, value interface{}) (cred
 	return cred, err
 }
 
+// SetCredentialIfVersion sets a credential of any type with a user-provided value,
+// only if the ID of its current version is expectedVersionID. The current
+// version is checked immediately before the value is set. If it is a different
+// version, a VersionConflictError is returned and nothing is set.
+//
+// Use UpdateCredential to read, modify and set a credential, retrying when
+// it is changed in between.
+func (ch *CredHub) SetCredentialIfVersion(name, credType string, value interface{}, expectedVersionID string) (credentials.Credential, error) {
+	var cred credentials.Credential
+	err := ch.setCredentialIfVersion(name, credType, value, expectedVersionID, &cred)
+
+	return cred, err
+}
+
+func (ch *CredHub) setCredentialIfVersion(name, credType string, value interface{}, expectedVersionID string, cred interface{}) error {
+	current, err := ch.getUncachedCurrentCredential(name)
+	if err != nil {
+		return err
+	}
+
+	if current.Id != expectedVersionID {
+		return newVersionConflictError(name, expectedVersionID, current.Id)
+	}
+
+	return ch.setCredential(name, credType, value, cred)
+}
+
 func (ch *CredHub) setCredential(name, credType string, value interface{}, cred interface{}) error {
 	requestBody := map[string]interface{}{}
 	requestBody["name"] = name
@@ -97,6 +124,8 @@ func (ch *CredHub) setCredential(name, credType string, value interface{}, cred
 		requestBody["mode"] = "overwrite"
 	}
 
+	defer ch.invalidateCache(name)
+
 	resp, err := ch.Request(http.MethodPut, "/api/v1/data", nil, requestBody, true)
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package credhub
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net"
 	"net/url"
@@ -20,87 +21,140 @@ type ProxyDialer interface {
 
 func (f DialFunc) Dial(network, address string) (net.Conn, error) { return f(network, address) }
 
-func SOCKS5DialFuncFromEnvironment(origDialer DialFunc, socks5Proxy ProxyDialer) DialFunc {
-	allProxy := os.Getenv("CREDHUB_PROXY")
-	if len(allProxy) == 0 {
-		return origDialer
+// ProxyFromEnvironment returns the proxy set by CREDHUB_PROXY or, when it is
+// not set, by BOSH_ALL_PROXY
+func ProxyFromEnvironment() string {
+	if allProxy := os.Getenv("CREDHUB_PROXY"); allProxy != "" {
+		return allProxy
 	}
+	return os.Getenv("BOSH_ALL_PROXY")
+}
 
-	if strings.HasPrefix(allProxy, "ssh+") {
-		allProxy = strings.TrimPrefix(allProxy, "ssh+")
-
-		proxyURL, err := url.Parse(allProxy)
-		if err != nil {
-			return origDialer
+// SOCKS5DialFuncFromEnvironment wraps origDialer with the proxy returned by
+// ProxyFromEnvironment. If the proxy is not valid, the returned function
+// returns the error instead of connecting without the proxy.
+//
+// Deprecated: Use ProxyDialFunc, which returns the error right away.
+func SOCKS5DialFuncFromEnvironment(origDialer DialFunc, socks5Proxy ProxyDialer) DialFunc {
+	dial, err := ProxyDialFunc(ProxyFromEnvironment(), origDialer, socks5Proxy)
+	if err != nil {
+		return func(network, address string) (net.Conn, error) {
+			return nil, err
 		}
+	}
+	return dial
+}
 
-		queryMap, err := url.ParseQuery(proxyURL.RawQuery)
-		if err != nil {
-			return origDialer
-		}
+// ProxyDialFunc wraps origDialer with the proxy at proxyURL. An empty proxyURL
+// returns origDialer. Hosts listed in no_proxy are connected to directly.
+//
+// A SOCKS5 proxy is provided as e.g. 'socks5://localhost:1080'. A SOCKS5 proxy
+// through an SSH tunnel, e.g. to a jumpbox, is provided in the format used by
+// BOSH_ALL_PROXY:
+//
+//	ssh+socks5://jumpbox@jumpbox.example.com:22?private-key=/path/to/key&known-hosts=/path/to/known_hosts
+//
+// With known-hosts, the host key of the SSH server must match a key for its
+// host in the known_hosts file. Without it, ~/.ssh/known_hosts is used when it
+// has keys for the host, and any host key is accepted otherwise.
+//
+// socks5Proxy opens the SSH tunnel. When it is nil, the tunnel is opened as
+// described above.
+func ProxyDialFunc(proxyURL string, origDialer DialFunc, socks5Proxy ProxyDialer) (DialFunc, error) {
+	if len(proxyURL) == 0 {
+		return origDialer, nil
+	}
 
-		proxySSHKeyPath, ok := queryMap["private-key"]
-		if !ok {
-			return origDialer
-		}
+	if strings.HasPrefix(proxyURL, "ssh+") {
+		return sshProxyDialFunc(strings.TrimPrefix(proxyURL, "ssh+"), socks5Proxy)
+	}
 
-		username := ""
-		if proxyURL.User != nil {
-			username = proxyURL.User.Username()
-		}
+	parsedURL, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy: %s", err)
+	}
 
-		if len(proxySSHKeyPath) == 0 {
-			return origDialer
-		}
+	proxy, err := goproxy.FromURL(parsedURL, origDialer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy: %s", err)
+	}
 
-		proxySSHKey, err := ioutil.ReadFile(proxySSHKeyPath[0])
-		if err != nil {
-			return origDialer
-		}
+	noProxy := os.Getenv("no_proxy")
+	if len(noProxy) == 0 {
+		return proxy.Dial, nil
+	}
 
-		var (
-			dialer proxy.DialFunc
-			mut    sync.RWMutex
-		)
-		return func(network, address string) (net.Conn, error) {
-			mut.RLock()
-			haveDialer := dialer != nil
-			mut.RUnlock()
+	perHost := goproxy.NewPerHost(proxy, origDialer)
+	perHost.AddFromString(noProxy)
 
-			if haveDialer {
-				return dialer(network, address)
-			}
+	return perHost.Dial, nil
+}
 
-			mut.Lock()
-			defer mut.Unlock()
-			if dialer == nil {
-				proxyDialer, err := socks5Proxy.Dialer(username, string(proxySSHKey), proxyURL.Host)
-				if err != nil {
-					return nil, err
-				}
-				dialer = proxyDialer
-			}
-			return dialer(network, address)
-		}
+func sshProxyDialFunc(proxyURL string, socks5Proxy ProxyDialer) (DialFunc, error) {
+	parsedURL, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy: %s", err)
+	}
+	if parsedURL.Scheme != "socks5" || parsedURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy: an SSH proxy must be of the form 'ssh+socks5://user@host:port?private-key=/path/to/key'")
 	}
 
-	proxyURL, err := url.Parse(allProxy)
+	queryMap, err := url.ParseQuery(parsedURL.RawQuery)
 	if err != nil {
-		return origDialer
+		return nil, fmt.Errorf("invalid proxy: %s", err)
+	}
+
+	proxySSHKeyPath := queryMap.Get("private-key")
+	if proxySSHKeyPath == "" {
+		return nil, fmt.Errorf("invalid proxy: the 'private-key' parameter of an SSH proxy is required")
 	}
 
-	proxy, err := goproxy.FromURL(proxyURL, origDialer)
+	proxySSHKey, err := ioutil.ReadFile(proxySSHKeyPath)
 	if err != nil {
-		return origDialer
+		return nil, fmt.Errorf("invalid proxy: could not read the private key: %s", err)
 	}
 
-	noProxy := os.Getenv("no_proxy")
-	if len(noProxy) == 0 {
-		return proxy.Dial
+	if socks5Proxy == nil {
+		if knownHostsPath := queryMap.Get("known-hosts"); knownHostsPath != "" {
+			socks5Proxy, err = newKnownHostsProxyDialer(knownHostsPath, parsedURL.Host)
+			if err != nil {
+				return nil, fmt.Errorf("invalid proxy: %s", err)
+			}
+		} else {
+			socks5Proxy, err = defaultKnownHostsProxyDialer(parsedURL.Host)
+			if err != nil {
+				return nil, fmt.Errorf("invalid proxy: %s", err)
+			}
+		}
 	}
 
-	perHost := goproxy.NewPerHost(proxy, origDialer)
-	perHost.AddFromString(noProxy)
+	username := ""
+	if parsedURL.User != nil {
+		username = parsedURL.User.Username()
+	}
 
-	return perHost.Dial
+	var (
+		dialer proxy.DialFunc
+		mut    sync.RWMutex
+	)
+	return func(network, address string) (net.Conn, error) {
+		mut.RLock()
+		haveDialer := dialer != nil
+		mut.RUnlock()
+
+		if haveDialer {
+			return dialer(network, address)
+		}
+
+		mut.Lock()
+		defer mut.Unlock()
+		if dialer == nil {
+			proxyDialer, err := socks5Proxy.Dialer(username, string(proxySSHKey), parsedURL.Host)
+			if err != nil {
+				return nil, err
+			}
+			dialer = proxyDialer
+		}
+		return dialer(network, address)
+	}, nil
 }
@@ -1,6 +1,14 @@
 package credhub_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -14,6 +22,7 @@ import (
 	"github.com/cloudfoundry/socks5-proxy"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
 )
 
 var _ = Describe("Socksify", func() {
@@ -25,6 +34,7 @@ var _ = Describe("Socksify", func() {
 
 	BeforeEach(func() {
 		os.Unsetenv("CREDHUB_PROXY")
+		os.Unsetenv("BOSH_ALL_PROXY")
 		os.Unsetenv("https_proxy")
 		proxyDialer = &FakeProxyDialer{}
 		origDial = credhub.DialFunc(func(x, y string) (net.Conn, error) {
@@ -99,9 +109,9 @@ var _ = Describe("Socksify", func() {
 					os.Setenv("CREDHUB_PROXY", fmt.Sprintf("ssh+:cannot-start-with-colon"))
 					dialFunc = credhub.SOCKS5DialFuncFromEnvironment(origDial, proxyDialer)
 				})
-				It("returns the dialer that was passed in", func() {
+				It("returns an error instead of connecting without the proxy", func() {
 					_, err := dialFunc("", "")
-					Expect(err).To(MatchError("original dialer"))
+					Expect(err).To(MatchError(ContainSubstring("invalid proxy: parse")))
 					os.Unsetenv("CREDHUB_PROXY")
 				})
 			})
@@ -111,9 +121,9 @@ var _ = Describe("Socksify", func() {
 					os.Setenv("CREDHUB_PROXY", fmt.Sprintf("ssh+socks5://localhost:12345?foo=%%"))
 					dialFunc = credhub.SOCKS5DialFuncFromEnvironment(origDial, proxyDialer)
 				})
-				It("returns the dialer that was passed in", func() {
+				It("returns an error instead of connecting without the proxy", func() {
 					_, err := dialFunc("", "")
-					Expect(err).To(MatchError("original dialer"))
+					Expect(err).To(MatchError(ContainSubstring("invalid proxy: invalid URL escape")))
 					os.Unsetenv("CREDHUB_PROXY")
 				})
 			})
@@ -123,9 +133,9 @@ var _ = Describe("Socksify", func() {
 					os.Setenv("CREDHUB_PROXY", fmt.Sprintf("ssh+socks5://localhost:12345?foo=bar"))
 					dialFunc = credhub.SOCKS5DialFuncFromEnvironment(origDial, proxyDialer)
 				})
-				It("returns the dialer that was passed in", func() {
+				It("returns an error instead of connecting without the proxy", func() {
 					_, err := dialFunc("", "")
-					Expect(err).To(MatchError("original dialer"))
+					Expect(err).To(MatchError("invalid proxy: the 'private-key' parameter of an SSH proxy is required"))
 					os.Unsetenv("CREDHUB_PROXY")
 				})
 			})
@@ -135,9 +145,9 @@ var _ = Describe("Socksify", func() {
 					os.Setenv("CREDHUB_PROXY", fmt.Sprintf("ssh+socks5://localhost:12345?private-key=/no/file/here"))
 					dialFunc = credhub.SOCKS5DialFuncFromEnvironment(origDial, proxyDialer)
 				})
-				It("returns the dialer that was passed in", func() {
+				It("returns an error instead of connecting without the proxy", func() {
 					_, err := dialFunc("", "")
-					Expect(err).To(MatchError("original dialer"))
+					Expect(err).To(MatchError(ContainSubstring("invalid proxy: could not read the private key")))
 					os.Unsetenv("CREDHUB_PROXY")
 				})
 			})
@@ -150,9 +160,9 @@ var _ = Describe("Socksify", func() {
 					os.Setenv("CREDHUB_PROXY", fmt.Sprintf(":cannot-start-with-colon"))
 					dialFunc = credhub.SOCKS5DialFuncFromEnvironment(origDial, proxyDialer)
 				})
-				It("returns the dialer that was passed in", func() {
+				It("returns an error instead of connecting without the proxy", func() {
 					_, err := dialFunc("", "")
-					Expect(err).To(MatchError("original dialer"))
+					Expect(err).To(MatchError(ContainSubstring("invalid proxy: parse")))
 					os.Unsetenv("CREDHUB_PROXY")
 				})
 			})
@@ -162,9 +172,9 @@ var _ = Describe("Socksify", func() {
 					os.Setenv("CREDHUB_PROXY", fmt.Sprintf("foo://cannot-start-with-colon"))
 					dialFunc = credhub.SOCKS5DialFuncFromEnvironment(origDial, proxyDialer)
 				})
-				It("returns the dialer that was passed in", func() {
+				It("returns an error instead of connecting without the proxy", func() {
 					_, err := dialFunc("", "")
-					Expect(err).To(MatchError("original dialer"))
+					Expect(err).To(MatchError(ContainSubstring("invalid proxy: proxy: unknown scheme: foo")))
 					os.Unsetenv("CREDHUB_PROXY")
 				})
 			})
@@ -172,6 +182,185 @@ var _ = Describe("Socksify", func() {
 	})
 })
 
+var _ = Describe("ProxyFromEnvironment", func() {
+	AfterEach(func() {
+		os.Unsetenv("CREDHUB_PROXY")
+		os.Unsetenv("BOSH_ALL_PROXY")
+	})
+
+	It("falls back to BOSH_ALL_PROXY when CREDHUB_PROXY is not set", func() {
+		os.Setenv("BOSH_ALL_PROXY", "socks5://bosh:1080")
+		Expect(credhub.ProxyFromEnvironment()).To(Equal("socks5://bosh:1080"))
+
+		os.Setenv("CREDHUB_PROXY", "socks5://credhub:1080")
+		Expect(credhub.ProxyFromEnvironment()).To(Equal("socks5://credhub:1080"))
+	})
+
+	It("makes New return an error when the proxy is not valid", func() {
+		os.Setenv("BOSH_ALL_PROXY", "ssh+socks5://jumpbox@localhost:22")
+
+		_, err := credhub.New("https://example.com")
+		Expect(err).To(MatchError("invalid proxy: the 'private-key' parameter of an SSH proxy is required"))
+
+		_, err = credhub.New("http://example.com", credhub.Proxy("foo://localhost:1080"))
+		Expect(err).To(MatchError("invalid proxy: proxy: unknown scheme: foo"))
+
+		_, err = credhub.New("https://example.com", credhub.Proxy("socks5://localhost:1080"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+var _ = Describe("ProxyDialFunc with known hosts", func() {
+	var (
+		tempDir        string
+		privateKeyPath string
+		hostKey        ssh.Signer
+		listener       net.Listener
+		origDial       credhub.DialFunc
+	)
+
+	writeKnownHosts := func(line string) string {
+		path := filepath.Join(tempDir, "known_hosts")
+		Expect(ioutil.WriteFile(path, []byte("# comment\n"+line), 0600)).To(Succeed())
+		return path
+	}
+
+	proxyURL := func(knownHostsPath string) string {
+		return fmt.Sprintf("ssh+socks5://jumpbox@%s?private-key=%s&known-hosts=%s", listener.Addr(), privateKeyPath, knownHostsPath)
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		der, err := x509.MarshalECPrivateKey(clientKey)
+		Expect(err).NotTo(HaveOccurred())
+		privateKeyPath = filepath.Join(tempDir, "id_ecdsa")
+		Expect(ioutil.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600)).To(Succeed())
+
+		serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		hostKey, err = ssh.NewSignerFromKey(serverKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		config := &ssh.ServerConfig{
+			PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) {
+				return nil, nil
+			},
+		}
+		config.AddHostKey(hostKey)
+
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					_, channels, requests, err := ssh.NewServerConn(conn, config)
+					if err != nil {
+						return
+					}
+					go ssh.DiscardRequests(requests)
+					for channel := range channels {
+						channel.Reject(ssh.Prohibited, "tunnel reached")
+					}
+				}()
+			}
+		}()
+
+		origDial = credhub.DialFunc(func(x, y string) (net.Conn, error) {
+			return nil, errors.New("original dialer")
+		})
+	})
+
+	AfterEach(func() {
+		listener.Close()
+		os.RemoveAll(tempDir)
+	})
+
+	It("connects when the host key is pinned", func() {
+		_, port, _ := net.SplitHostPort(listener.Addr().String())
+		knownHosts := writeKnownHosts("[127.0.0.1]:" + port + " " + string(ssh.MarshalAuthorizedKey(hostKey.PublicKey())))
+
+		dial, err := credhub.ProxyDialFunc(proxyURL(knownHosts), origDial, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dial("tcp", "credhub.example.com:8844")
+		Expect(err).To(MatchError(ContainSubstring("tunnel reached")))
+	})
+
+	It("connects when the host key is pinned with a hashed host name", func() {
+		_, port, _ := net.SplitHostPort(listener.Addr().String())
+		salt := []byte("0123456789abcdefghij")
+		mac := hmac.New(sha1.New, salt)
+		mac.Write([]byte("[127.0.0.1]:" + port))
+		hashed := "|1|" + base64.StdEncoding.EncodeToString(salt) + "|" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		knownHosts := writeKnownHosts(hashed + " " + string(ssh.MarshalAuthorizedKey(hostKey.PublicKey())))
+
+		dial, err := credhub.ProxyDialFunc(proxyURL(knownHosts), origDial, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dial("tcp", "credhub.example.com:8844")
+		Expect(err).To(MatchError(ContainSubstring("tunnel reached")))
+	})
+
+	It("refuses to connect when the host key does not match", func() {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		otherPublicKey, err := ssh.NewPublicKey(&otherKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+		knownHosts := writeKnownHosts("127.0.0.1,[127.0.0.1]:* " + string(ssh.MarshalAuthorizedKey(otherPublicKey)))
+
+		dial, err := credhub.ProxyDialFunc(proxyURL(knownHosts), origDial, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dial("tcp", "credhub.example.com:8844")
+		Expect(err).To(MatchError(ContainSubstring("does not match the known hosts")))
+	})
+
+	It("checks the host key against ~/.ssh/known_hosts when known-hosts is not provided", func() {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		otherPublicKey, err := ssh.NewPublicKey(&otherKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Mkdir(filepath.Join(tempDir, ".ssh"), 0700)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(tempDir, ".ssh", "known_hosts"), []byte("127.0.0.1,[127.0.0.1]:* "+string(ssh.MarshalAuthorizedKey(otherPublicKey))), 0600)).To(Succeed())
+
+		home := os.Getenv("HOME")
+		os.Setenv("HOME", tempDir)
+		defer os.Setenv("HOME", home)
+
+		dial, err := credhub.ProxyDialFunc(fmt.Sprintf("ssh+socks5://jumpbox@%s?private-key=%s", listener.Addr(), privateKeyPath), origDial, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dial("tcp", "credhub.example.com:8844")
+		Expect(err).To(MatchError(ContainSubstring("does not match the known hosts")))
+	})
+
+	It("returns an error naming the host when known-hosts is not provided and the host is not in ~/.ssh/known_hosts", func() {
+		home := os.Getenv("HOME")
+		os.Setenv("HOME", tempDir)
+		defer os.Setenv("HOME", home)
+
+		_, err := credhub.ProxyDialFunc(fmt.Sprintf("ssh+socks5://jumpbox@%s?private-key=%s", listener.Addr(), privateKeyPath), origDial, nil)
+		Expect(err).To(MatchError(ContainSubstring("the host key of '[127.0.0.1]:")))
+		Expect(err).To(MatchError(ContainSubstring("ssh-keyscan -p")))
+	})
+
+	It("returns an error when the host is not in the known hosts", func() {
+		knownHosts := writeKnownHosts("other.example.com " + string(ssh.MarshalAuthorizedKey(hostKey.PublicKey())))
+
+		_, err := credhub.ProxyDialFunc(proxyURL(knownHosts), origDial, nil)
+		Expect(err).To(MatchError(ContainSubstring("no host key for '[127.0.0.1]:")))
+	})
+})
+
 type FakeProxyDialer struct {
 	DialerCall struct {
 		CallCount int
@@ -0,0 +1,51 @@
+package credhub
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
+)
+
+// UpdateCredential reads the current version of a credential, passes it to
+// modify and sets the returned value as a new version with the same type, e.g.
+//
+//	cred, err := ch.UpdateCredential("/example-counter", 3, func(current credentials.Credential) (interface{}, error) {
+//		count, _ := strconv.Atoi(current.Value.(string))
+//		return strconv.Itoa(count + 1), nil
+//	})
+//
+// If the credential is changed by another client in between, the new value is
+// not set and the credential is read again and passed to modify again, up to
+// maxAttempts times in total. The last VersionConflictError is returned when all
+// attempts conflict. Errors returned by modify are returned without retrying.
+func (ch *CredHub) UpdateCredential(name string, maxAttempts int, modify func(current credentials.Credential) (interface{}, error)) (credentials.Credential, error) {
+	var err error
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		var current, cred credentials.Credential
+
+		current, err = ch.getUncachedCurrentCredential(name)
+		if err != nil {
+			return cred, err
+		}
+
+		var value interface{}
+		value, err = modify(current)
+		if err != nil {
+			return cred, err
+		}
+
+		cred, err = ch.SetCredentialIfVersion(name, current.Type, value, current.Id)
+
+		var conflict *VersionConflictError
+		if !errors.As(err, &conflict) {
+			return cred, err
+		}
+	}
+
+	if err == nil {
+		err = errors.New("maxAttempts must be positive")
+	}
+
+	return credentials.Credential{}, err
+}
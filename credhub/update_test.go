@@ -0,0 +1,139 @@
+package credhub_test
+
+import (
+	"errors"
+	"net/http"
+
+	. "code.cloudfoundry.org/credhub-cli/credhub"
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Compare-and-set", func() {
+	var (
+		server *ghttp.Server
+		ch     *CredHub
+	)
+
+	currentVersion := func(id, value string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/data", "current=true&name=/example-value"),
+			ghttp.RespondWith(http.StatusOK, `{"data":[{"id":"`+id+`","name":"/example-value","type":"value","value":"`+value+`"}]}`),
+		)
+	}
+
+	expectSet := func(value string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", "/api/v1/data"),
+			ghttp.VerifyJSON(`{"name":"/example-value","type":"value","value":"`+value+`"}`),
+			ghttp.RespondWith(http.StatusOK, `{"id":"new-id","name":"/example-value","type":"value","value":"`+value+`"}`),
+		)
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		ch, _ = New(server.URL(), ServerVersion("2.0.0"))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("SetCredentialIfVersion()", func() {
+		It("sets the credential when the current version is the expected one", func() {
+			server.AppendHandlers(currentVersion("id-1", "1"), expectSet("2"))
+
+			cred, err := ch.SetCredentialIfVersion("/example-value", "value", "2", "id-1")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cred.Id).To(Equal("new-id"))
+		})
+
+		It("returns a VersionConflictError without setting anything when the current version is a different one", func() {
+			server.AppendHandlers(currentVersion("id-2", "1"))
+
+			_, err := ch.SetCredentialIfVersion("/example-value", "value", "2", "id-1")
+
+			var versionConflict *VersionConflictError
+			Expect(errors.As(err, &versionConflict)).To(BeTrue())
+			Expect(versionConflict.ExpectedId).To(Equal("id-1"))
+			Expect(versionConflict.CurrentId).To(Equal("id-2"))
+
+			var conflict *ConflictError
+			Expect(errors.As(err, &conflict)).To(BeTrue())
+			Expect(err).To(MatchError("The credential '/example-value' was changed by another client: expected version 'id-1' but the current version is 'id-2'. Please retry your request."))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns the error when the current version cannot be read", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `{"error":"The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`))
+
+			_, err := ch.SetCredentialIfVersion("/example-value", "value", "2", "id-1")
+
+			var notFound *NotFoundError
+			Expect(errors.As(err, &notFound)).To(BeTrue())
+		})
+	})
+
+	Describe("UpdateCredential()", func() {
+		increment := func(current credentials.Credential) (interface{}, error) {
+			if current.Value == "1" {
+				return "2", nil
+			}
+			return "3", nil
+		}
+
+		It("sets the modified value", func() {
+			server.AppendHandlers(currentVersion("id-1", "1"), currentVersion("id-1", "1"), expectSet("2"))
+
+			cred, err := ch.UpdateCredential("/example-value", 3, increment)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cred.Id).To(Equal("new-id"))
+		})
+
+		It("reads and modifies the credential again when it changed in between", func() {
+			server.AppendHandlers(
+				currentVersion("id-1", "1"),
+				currentVersion("id-2", "2"),
+				currentVersion("id-2", "2"),
+				currentVersion("id-2", "2"),
+				expectSet("3"),
+			)
+
+			cred, err := ch.UpdateCredential("/example-value", 3, increment)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cred.Value).To(Equal("3"))
+		})
+
+		It("returns the conflict after the last attempt", func() {
+			server.AppendHandlers(
+				currentVersion("id-1", "1"),
+				currentVersion("id-2", "1"),
+				currentVersion("id-2", "1"),
+				currentVersion("id-3", "1"),
+			)
+
+			_, err := ch.UpdateCredential("/example-value", 2, increment)
+
+			var conflict *VersionConflictError
+			Expect(errors.As(err, &conflict)).To(BeTrue())
+			Expect(server.ReceivedRequests()).To(HaveLen(4))
+		})
+
+		It("does not retry errors returned by modify", func() {
+			server.AppendHandlers(currentVersion("id-1", "1"))
+
+			_, err := ch.UpdateCredential("/example-value", 3, func(credentials.Credential) (interface{}, error) {
+				return nil, errors.New("some error")
+			})
+
+			Expect(err).To(MatchError("some error"))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,181 @@
+package credhub
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/credhub-cli/credhub/credentials"
+)
+
+// MaxWatchBackoff is the longest time Watch waits between polls after errors.
+const MaxWatchBackoff = 5 * time.Minute
+
+// WatchEvent is emitted by Watch when a credential is first read, when its
+// current version changes, or when it cannot be read.
+type WatchEvent struct {
+	// Name of the watched credential, as passed to Watch. Empty when the
+	// credentials to watch could not be listed.
+	Name string
+
+	// Current version of the credential. Unset when Err is set.
+	Credential credentials.Credential
+
+	// ID of the version seen before this one. Empty for the initial read.
+	PreviousId string
+
+	// Initial is true for the first successful read of the credential
+	Initial bool
+
+	// Err is set when the credential could not be read
+	Err error
+}
+
+// Watch polls the current version of each named credential every interval
+// and emits an event when a credential is first read and whenever its
+// version ID changes.
+//
+// Errors are emitted as events as well. After a failed poll of a credential,
+// the time until its next poll doubles, up to MaxWatchBackoff (or interval, if
+// that is longer), and is reset after a successful poll. Other credentials
+// are still polled every interval. The returned channel is closed once ctx
+// is done.
+func (ch *CredHub) Watch(ctx context.Context, names []string, interval time.Duration) <-chan WatchEvent {
+	return ch.WatchFunc(ctx, func() ([]string, error) { return names, nil }, interval)
+}
+
+// WatchFunc is like Watch, but calls names every interval to get the
+// credentials to watch, e.g. to watch the credentials found under a path as
+// they are added or removed.
+//
+// When names fails, the error is emitted as an event without a Name, the
+// credentials listed before are polled, and listing backs off like a poll.
+func (ch *CredHub) WatchFunc(ctx context.Context, names func() ([]string, error), interval time.Duration) <-chan WatchEvent {
+	events := make(chan WatchEvent)
+
+	go func() {
+		defer close(events)
+
+		send := func(event WatchEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		listing := &watchState{}
+		states := map[string]*watchState{}
+		var watched []string
+
+		for {
+			now := time.Now()
+
+			if listing.due(now) {
+				listed, err := names()
+				listing.schedule(now, err != nil, interval)
+				if err != nil {
+					if !send(WatchEvent{Err: err}) {
+						return
+					}
+				} else {
+					watched = listed
+					forgetUnwatched(states, watched)
+				}
+			}
+
+			next := listing.next
+			for _, name := range watched {
+				state, ok := states[name]
+				if !ok {
+					state = &watchState{}
+					states[name] = state
+				}
+
+				if state.due(now) {
+					event, changed := ch.poll(name, state)
+					state.schedule(now, event.Err != nil, interval)
+					if changed && !send(event) {
+						return
+					}
+				}
+
+				if state.next.Before(next) {
+					next = state.next
+				}
+			}
+
+			select {
+			case <-time.After(time.Until(next)):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// watchState is the last seen version of a watched credential and the time
+// of its next poll
+type watchState struct {
+	version string
+	seen    bool
+	wait    time.Duration
+	next    time.Time
+}
+
+func (s *watchState) due(now time.Time) bool {
+	return !now.Before(s.next)
+}
+
+// schedule sets the time of the next poll, backing off after a failed poll
+func (s *watchState) schedule(now time.Time, failed bool, interval time.Duration) {
+	if failed {
+		s.wait = nextBackoff(s.wait, interval)
+	} else {
+		s.wait = interval
+	}
+	s.next = now.Add(s.wait)
+}
+
+func forgetUnwatched(states map[string]*watchState, watched []string) {
+	keep := map[string]bool{}
+	for _, name := range watched {
+		keep[name] = true
+	}
+	for name := range states {
+		if !keep[name] {
+			delete(states, name)
+		}
+	}
+}
+
+func (ch *CredHub) poll(name string, state *watchState) (WatchEvent, bool) {
+	cred, err := ch.GetLatestVersion(name)
+	if err != nil {
+		return WatchEvent{Name: name, Err: err}, true
+	}
+
+	if state.seen && state.version == cred.Id {
+		return WatchEvent{}, false
+	}
+	previous, initial := state.version, !state.seen
+	state.version, state.seen = cred.Id, true
+
+	return WatchEvent{Name: name, Credential: cred, PreviousId: previous, Initial: initial}, true
+}
+
+func nextBackoff(wait, interval time.Duration) time.Duration {
+	if wait < interval {
+		wait = interval
+	}
+	wait *= 2
+	if limit := MaxWatchBackoff; wait > limit {
+		if interval > limit {
+			limit = interval
+		}
+		wait = limit
+	}
+	return wait
+}
@@ -0,0 +1,170 @@
+package credhub_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	. "code.cloudfoundry.org/credhub-cli/credhub"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Watch", func() {
+	var (
+		server *ghttp.Server
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	credentialResponse := func(id, value string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/data", "current=true&name=%2Fexample-password"),
+			ghttp.RespondWith(http.StatusOK, `{"data":[{"id":"`+id+`","name":"/example-password","type":"password","value":"`+value+`"}]}`),
+		)
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+		server.Close()
+	})
+
+	It("emits the initial version and each changed version", func() {
+		server.AppendHandlers(
+			credentialResponse("id-1", "first"),
+			credentialResponse("id-1", "first"),
+			credentialResponse("id-2", "second"),
+		)
+		server.AllowUnhandledRequests = true
+		server.UnhandledRequestStatusCode = http.StatusServiceUnavailable
+
+		ch, _ := New(server.URL())
+		events := ch.Watch(ctx, []string{"/example-password"}, 10*time.Millisecond)
+
+		var event WatchEvent
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Err).NotTo(HaveOccurred())
+		Expect(event.Initial).To(BeTrue())
+		Expect(event.Name).To(Equal("/example-password"))
+		Expect(event.Credential.Id).To(Equal("id-1"))
+		Expect(event.Credential.Value).To(Equal("first"))
+
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Err).NotTo(HaveOccurred())
+		Expect(event.Initial).To(BeFalse())
+		Expect(event.PreviousId).To(Equal("id-1"))
+		Expect(event.Credential.Id).To(Equal("id-2"))
+		Expect(event.Credential.Value).To(Equal("second"))
+	})
+
+	It("emits errors and keeps polling", func() {
+		server.AppendHandlers(
+			ghttp.RespondWith(http.StatusInternalServerError, `{"error":"something went wrong"}`),
+			credentialResponse("id-1", "first"),
+		)
+
+		ch, _ := New(server.URL())
+		events := ch.Watch(ctx, []string{"/example-password"}, 10*time.Millisecond)
+
+		var event WatchEvent
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Err).To(HaveOccurred())
+
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Err).NotTo(HaveOccurred())
+		Expect(event.Credential.Id).To(Equal("id-1"))
+	})
+
+	It("backs off only for the credentials that fail", func() {
+		var mu sync.Mutex
+		polls := map[string]int{}
+		server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+			name := r.URL.Query().Get("name")
+			mu.Lock()
+			polls[name]++
+			mu.Unlock()
+
+			if name == "/failing-password" {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"error":"something went wrong"}`))
+				return
+			}
+			w.Write([]byte(`{"data":[{"id":"id-1","name":"/example-password","type":"password","value":"first"}]}`))
+		})
+		pollsOf := func(name string) func() int {
+			return func() int {
+				mu.Lock()
+				defer mu.Unlock()
+				return polls[name]
+			}
+		}
+
+		ch, _ := New(server.URL())
+		events := ch.Watch(ctx, []string{"/example-password", "/failing-password"}, 10*time.Millisecond)
+		go func() {
+			for range events {
+			}
+		}()
+
+		Eventually(pollsOf("/example-password")).Should(BeNumerically(">=", 12))
+		Expect(pollsOf("/failing-password")()).To(BeNumerically("<=", 5))
+	})
+
+	It("watches the credentials listed on each poll", func() {
+		server.RouteToHandler("GET", "/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+			name := r.URL.Query().Get("name")
+			w.Write([]byte(`{"data":[{"id":"id` + name + `","name":"` + name + `","type":"password","value":"some-value"}]}`))
+		})
+
+		listed := [][]string{{"/first-password"}, {"/first-password", "/second-password"}}
+		names := func() ([]string, error) {
+			if len(listed) > 1 {
+				defer func() { listed = listed[1:] }()
+			}
+			return listed[0], nil
+		}
+
+		ch, _ := New(server.URL())
+		events := ch.WatchFunc(ctx, names, 10*time.Millisecond)
+
+		var event WatchEvent
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Name).To(Equal("/first-password"))
+		Expect(event.Initial).To(BeTrue())
+
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Name).To(Equal("/second-password"))
+		Expect(event.Initial).To(BeTrue())
+	})
+
+	It("emits listing errors without a name", func() {
+		ch, _ := New(server.URL())
+		events := ch.WatchFunc(ctx, func() ([]string, error) {
+			return nil, errors.New("listing failed")
+		}, 10*time.Millisecond)
+
+		var event WatchEvent
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Name).To(BeEmpty())
+		Expect(event.Err).To(MatchError("listing failed"))
+	})
+
+	It("closes the channel when the context is done", func() {
+		server.AllowUnhandledRequests = true
+
+		ch, _ := New(server.URL())
+		events := ch.Watch(ctx, []string{"/example-password"}, 10*time.Millisecond)
+		cancel()
+
+		Eventually(events).Should(BeClosed())
+	})
+})
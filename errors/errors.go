@@ -3,14 +3,18 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/credhub-cli/credhub/auth"
+	"code.cloudfoundry.org/credhub-cli/credhub/network"
 )
 
 func NewNetworkError(e error) error {
-	return errors.New(fmt.Sprintf("Error connecting to the targeted API: %#v. Please validate your target and retry your request.", e.Error()))
+	return &network.Error{Err: errors.New(fmt.Sprintf("Error connecting to the targeted API: %#v. Please validate your target and retry your request.", e.Error()))}
 }
 
 func NewAuthServerNetworkError(e error) error {
-	return errors.New(fmt.Sprintf("Error connecting to the auth server: %#v. Please validate your target and retry your request.", e.Error()))
+	return &network.Error{Err: errors.New(fmt.Sprintf("Error connecting to the auth server: %#v. Please validate your target and retry your request.", e.Error()))}
 }
 
 func NewCatchAllError() error {
@@ -22,7 +26,7 @@ func NewEmptyTemplateError(filepath string) error {
 }
 
 func NewRevokedTokenError() error {
-	return errors.New("You are not currently authenticated. Please log in to continue.")
+	return &auth.Error{Err: errors.New("You are not currently authenticated. Please log in to continue.")}
 }
 
 func NewFileLoadError() error {
@@ -50,7 +54,7 @@ func NewClientAuthorizationParametersError() error {
 }
 
 func NewRefreshError() error {
-	return errors.New("You are not currently authenticated. Please log in to continue.")
+	return &auth.Error{Err: errors.New("You are not currently authenticated. Please log in to continue.")}
 }
 
 func NewNoMatchingCredentialsFoundError() error {
@@ -77,10 +81,6 @@ func NewNoCredentialsTag() error {
 	return errors.New("The referenced import file does not begin with the key 'credentials'. The import file must contain a list of credentials under the key 'credentials'. Please update and retry your request.")
 }
 
-func NewGetVersionAndKeyError() error {
-	return errors.New("The --version flag and --key flag are incompatible")
-}
-
 func NewUserNameOnlyValidForUserType() error {
 	return errors.New("Username parameter is not valid for this credential type.")
 }
@@ -88,3 +88,183 @@ func NewUserNameOnlyValidForUserType() error {
 func NewUAAError(err error) error {
 	return errors.New("UAA error: " + err.Error())
 }
+
+func NewInvalidInterpolateFormatError() error {
+	return errors.New("The provided format is not supported. Valid formats include 'yaml', 'json' and 'text'.")
+}
+
+func NewInvalidInterpolateEscapeError() error {
+	return errors.New("The provided escaping is not supported. Valid escapings include 'none', 'json' and 'shell'.")
+}
+
+func NewEscapeOnlyValidForTextFormatError() error {
+	return errors.New("The --escape flag may only be used with the 'text' format.")
+}
+
+func NewNotFoundInPrefixesError(paths []string, err error) error {
+	return errors.New(fmt.Sprintf("None of the paths %s could be retrieved. Last error: %s", strings.Join(paths, ", "), err.Error()))
+}
+
+func NewInvalidOutputFormatError() error {
+	return errors.New("The provided output format is not supported. Valid formats include 'yaml', 'json', 'env', 'dotenv', 'raw' and 'template'.")
+}
+
+func NewMixedOutputFormatError() error {
+	return errors.New("The --output-json flag and --output flag are incompatible")
+}
+
+func NewMissingOutputTemplateError() error {
+	return errors.New("A template must be provided with the --template flag when using the 'template' output format.")
+}
+
+func NewTemplateOnlyValidForTemplateOutputError() error {
+	return errors.New("The --template flag may only be used with the 'template' output format.")
+}
+
+func NewInvalidOutputTemplateError(err error) error {
+	return errors.New("The provided template could not be rendered: " + err.Error())
+}
+
+func NewInvalidKeyPathError(key string) error {
+	return errors.New(fmt.Sprintf("The key '%s' is not a valid path. Use a field name or a path such as '.db.primary.password' or '.servers[0].host'.", key))
+}
+
+func NewMixedRollbackParametersError() error {
+	return errors.New("The --to-id flag and --steps flag are incompatible")
+}
+
+func NewInvalidRollbackStepsError() error {
+	return errors.New("The number of steps must be a positive number.")
+}
+
+func NewRollbackToCurrentVersionError() error {
+	return errors.New("The selected version is already the current version of the credential.")
+}
+
+func NewRollbackVersionMismatchError(id, name string) error {
+	return errors.New(fmt.Sprintf("The version '%s' does not belong to the credential '%s'.", id, name))
+}
+
+func NewNotEnoughVersionsError(steps, found int) error {
+	return errors.New(fmt.Sprintf("Cannot roll back %d version(s): only %d version(s) of the credential exist.", steps, found))
+}
+
+func NewRollbackAbortedError() error {
+	return errors.New("Rollback aborted.")
+}
+
+func NewMissingWatchNamesError() error {
+	return errors.New("A name or path must be provided. Please validate your input and retry your request.")
+}
+
+func NewInvalidWatchFileNameError(name string) error {
+	return errors.New(fmt.Sprintf("The name '%s' cannot be written to a file under the write directory.", name))
+}
+
+func NewInvalidWatchIntervalError() error {
+	return errors.New("The interval must be a positive duration, e.g. '30s' or '5m'.")
+}
+
+func NewInvalidAgentTTLError() error {
+	return errors.New("The TTL must not be negative, e.g. '5m' or '0s' to disable caching.")
+}
+
+func NewAgentAlreadyRunningError(socket string) error {
+	return errors.New(fmt.Sprintf("A credential agent is already listening on '%s'.", socket))
+}
+
+func NewUnknownShellError(shell string) error {
+	return errors.New(fmt.Sprintf("The shell '%s' is not supported. Valid shells include 'bash', 'zsh' and 'fish'.", shell))
+}
+
+func NewEditorFailedError(err error) error {
+	return errors.New(fmt.Sprintf("The editor exited with an error: %s. The credential was not changed.", err))
+}
+
+func NewEditInvalidYAMLError(err error) error {
+	return errors.New(fmt.Sprintf("The edited value is not valid YAML: %s. The credential was not changed.", err))
+}
+
+func NewEditInvalidValueError(credentialType, kind string) error {
+	return errors.New(fmt.Sprintf("The value of a %s credential must be %s. The credential was not changed.", credentialType, kind))
+}
+
+func NewEditUnknownFieldError(credentialType, field string) error {
+	return errors.New(fmt.Sprintf("The field '%s' is not valid for a %s credential. The credential was not changed.", field, credentialType))
+}
+
+func NewEditAbortedError() error {
+	return errors.New("Edit aborted.")
+}
+
+func NewJSONUpdateTypeError() error {
+	return errors.New("The merge and patch flags can only be used with the type 'json'.")
+}
+
+func NewMixedJSONUpdateParametersError() error {
+	return errors.New("The merge and patch flags cannot be combined with each other or with the flags that set the value, such as value or password. Please update your request to include only one of them.")
+}
+
+func NewInvalidJSONUpdateError(err error) error {
+	return errors.New(fmt.Sprintf("The provided merge or patch could not be parsed: %s. Please validate your input and retry your request.", err))
+}
+
+func NewIfVersionWithJSONUpdateError() error {
+	return errors.New("The if-version flag cannot be combined with the merge or patch flags, which always check the current version before setting the credential.")
+}
+
+func NewInvalidFindTimeError(value string) error {
+	return errors.New(fmt.Sprintf("The time '%s' could not be parsed. Please provide a date, e.g. '2017-01-01', or a time, e.g. '2017-01-01T12:00:00Z'.", value))
+}
+
+func NewInvalidFindGlobError(glob string) error {
+	return errors.New(fmt.Sprintf("The glob '%s' is not valid. Please validate your input and retry your request.", glob))
+}
+
+func NewInvalidFindRegexError(err error) error {
+	return errors.New(fmt.Sprintf("The regular expression is not valid: %s. Please validate your input and retry your request.", err))
+}
+
+func NewInvalidFindSortError() error {
+	return errors.New("The sort order is not valid. Valid orders include 'name' and 'updated'.")
+}
+
+func NewPasswordAuditFindingsError(reused, violations int) error {
+	return errors.New(fmt.Sprintf("The audit found %d group(s) of credentials sharing a password and %d password(s) that do not meet the policy.", reused, violations))
+}
+
+func NewInvalidAgeError(value string) error {
+	return errors.New(fmt.Sprintf("The age '%s' is not valid. Please provide a number of days, e.g. '90d', or a duration, e.g. '12h'.", value))
+}
+
+func NewInvalidRotateTypeError(credentialType string) error {
+	return errors.New(fmt.Sprintf("The type '%s' cannot be rotated. Valid types include 'password', 'user', 'ssh', 'rsa' and 'certificate'.", credentialType))
+}
+
+func NewInvalidRotateConcurrencyError() error {
+	return errors.New("The concurrency must be at least 1.")
+}
+
+func NewRotateFailedError(failed, total int) error {
+	return errors.New(fmt.Sprintf("%d of %d credentials could not be rotated. The errors are included in the report.", failed, total))
+}
+
+func NewInvalidAccessTokenError() error {
+	return &auth.Error{Err: errors.New("The access token could not be decoded. Please log in to continue.")}
+}
+
+func NewTokenVerificationError(e error) error {
+	return &auth.Error{Err: errors.New(fmt.Sprintf("The access token could not be verified by the auth server: %s. Please log in to continue.", e))}
+}
+
+func NewTokenClaimMismatchError(claim string) error {
+	return &auth.Error{Err: errors.New(fmt.Sprintf("The %s of the access token does not match the auth server. Please log in to continue.", claim))}
+}
+
+func NewInvalidAuthTypeError(authType string) error {
+	return errors.New(fmt.Sprintf("The auth type '%s' is not valid. Valid types include 'uaa' and 'oidc'.", authType))
+}
+
+func NewUnsupportedWithOidcError(option string) error {
+	return errors.New(fmt.Sprintf("The %s option is not supported when the auth server is an OpenID Connect provider. Please update and retry your request.", option))
+}
@@ -8,7 +8,6 @@ import (
 	"code.cloudfoundry.org/credhub-cli/commands"
 	"code.cloudfoundry.org/credhub-cli/config"
 	"code.cloudfoundry.org/credhub-cli/credhub"
-	"code.cloudfoundry.org/credhub-cli/credhub/auth"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -33,7 +32,13 @@ func main() {
 			cmd.SetConfig(config.ReadConfig())
 		}
 
-		if cmd, ok := command.(NeedsClient); ok {
+		if cmd, ok := command.(NeedsClient); ok && os.Getenv("CREDHUB_AGENT_SOCK") != "" && commands.ServedByAgent(command) {
+			client, err := commands.NewAgentClient(os.Getenv("CREDHUB_AGENT_SOCK"))
+			if err != nil {
+				return err
+			}
+			cmd.SetClient(client)
+		} else if cmd, ok := command.(NeedsClient); ok {
 			cfg := config.ReadConfig()
 			if err := config.ValidateConfig(cfg); err != nil {
 				return err
@@ -50,13 +55,11 @@ func main() {
 				credhub.AuthURL(cfg.AuthURL),
 				credhub.CaCerts(cfg.CaCerts...),
 				credhub.SkipTLSValidation(cfg.InsecureSkipVerify),
-				credhub.Auth(auth.Uaa(
+				credhub.Proxy(cfg.Proxy),
+				credhub.Auth(commands.AuthBuilder(
+					cfg,
 					clientId,
 					clientSecret,
-					"",
-					"",
-					cfg.AccessToken,
-					cfg.RefreshToken,
 					useClientCredentials,
 				)),
 				credhub.ServerVersion(cfg.ServerVersion),
@@ -78,6 +81,6 @@ func main() {
 	_, err := parser.Parse()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
-		os.Exit(1)
+		os.Exit(commands.ExitCode(err))
 	}
 }